@@ -0,0 +1,697 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+const typenameStr = "__typename"
+
+type fileList []string
+
+func (fl *fileList) String() string {
+	return strings.Join(*fl, " ")
+}
+
+func (fl *fileList) Set(s string) error {
+	*fl = append(*fl, s)
+	return nil
+}
+
+// clientGen generates a typed Go client for the named operations in one or
+// more executable documents.
+type clientGen struct {
+	root    *ggql.Root
+	exe     *ggql.Executable
+	types   strings.Builder
+	names   map[string]bool
+	enums   map[string]*ggql.Enum
+	inputs  map[string]*ggql.Input
+	imports map[string]bool
+}
+
+func newClientGen() *clientGen {
+	return &clientGen{
+		root:    ggql.NewRoot(nil),
+		names:   map[string]bool{},
+		enums:   map[string]*ggql.Enum{},
+		inputs:  map[string]*ggql.Input{},
+		imports: map[string]bool{},
+	}
+}
+
+// selField is a field in a selection set after fragments on the same type
+// have been merged in.
+type selField struct {
+	key  string
+	fd   *ggql.FieldDef
+	sels []ggql.Selection
+}
+
+// condSels are the selections of a fragment with a type condition that only
+// applies to some of the possible types of an interface or union.
+type condSels struct {
+	cond ggql.Type
+	sels []ggql.Selection
+}
+
+// clientMain runs the client sub-command and returns the exit code.
+func clientMain(args []string) int {
+	var schemas fileList
+	fs := flag.NewFlagSet("client", flag.ExitOnError)
+	fs.Var(&schemas, "s", "schema file, can be repeated")
+	pkgName := fs.String("p", "client", "package for output")
+	out := fs.String("o", "", "file to write the client to. If not provided the client is written to stdout")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `
+ggqlgen client generates a typed Go client for the named operations in the
+executable files. Each operation is validated against the schema. For each
+operation a response struct, a variables struct if the operation has
+variables, and a Client method that sends the operation and decodes the
+response are generated. Fragments on interfaces and unions are decoded into
+a field for each possible type based on the __typename which is added to
+the operation selections as needed.
+
+Usage: ggqlgen client -s <schema-file> [options] <operation-file>...
+
+`)
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	g := newClientGen()
+	var buf []byte
+	for _, path := range schemas {
+		sdl, err := getSDL(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read schema file %s: %s\n", path, err)
+			return 2
+		}
+		buf = append(buf, sdl...)
+		buf = append(buf, '\n')
+	}
+	if err := g.root.Parse(buf); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to parse schema: %s\n", err)
+		return 2
+	}
+	buf = buf[:0]
+	for _, path := range fs.Args() {
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read operation file %s: %s\n", path, err)
+			return 2
+		}
+		buf = append(buf, src...)
+		buf = append(buf, '\n')
+	}
+	src, err := g.generate(*pkgName, buf)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate client: %s\n", err)
+		return 1
+	}
+	if len(*out) == 0 {
+		fmt.Print(string(src))
+	} else if err = ioutil.WriteFile(*out, src, 0600); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write %s: %s\n", *out, err)
+		return 1
+	}
+	return 0
+}
+
+func (g *clientGen) generate(pkgName string, src []byte) ([]byte, error) {
+	var err error
+	if g.exe, err = g.root.ParseExecutable(src); err != nil {
+		return nil, err
+	}
+	if errs := g.exe.Validate(g.root); 0 < len(errs) {
+		return nil, ggql.Errors(errs)
+	}
+	opNames := make([]string, 0, len(g.exe.Ops))
+	for name := range g.exe.Ops {
+		if 0 < len(name) {
+			opNames = append(opNames, name)
+		}
+	}
+	sort.Strings(opNames)
+
+	var ops strings.Builder
+	for _, name := range opNames {
+		if err = g.genOp(&ops, g.exe.Ops[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err = g.genInputs(); err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	b.WriteString("// Code generated by ggqlgen. DO NOT EDIT.\n\n")
+	b.WriteString(fmt.Sprintf("package %s\n\n", pkgName))
+	b.WriteString("import (\n\t\"bytes\"\n\t\"context\"\n\t\"encoding/json\"\n\t\"fmt\"\n\t\"net/http\"\n\t\"strings\"\n")
+	if g.imports["time"] {
+		b.WriteString("\t\"time\"\n")
+	}
+	b.WriteString(")\n")
+	b.WriteString(clientSupport)
+	b.WriteString(ops.String())
+	b.WriteString(g.types.String())
+
+	return format.Source([]byte(b.String()))
+}
+
+func (g *clientGen) genOp(b *strings.Builder, op *ggql.Op) (err error) {
+	public := publicName(op.Name)
+	opType := g.root.Schema().GetField(string(op.Type))
+	if opType == nil {
+		return fmt.Errorf("the schema does not support %s operations", op.Type)
+	}
+	frags := map[string]*ggql.Fragment{}
+	op.Sels = g.addTypename(opType.Type, op.Sels, frags, false)
+
+	var query strings.Builder
+	query.WriteString(op.String())
+	names := make([]string, 0, len(frags))
+	for name := range frags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		query.WriteString("\n")
+		query.WriteString(frags[name].String())
+	}
+	queryConst := strings.ToLower(public[:1]) + public[1:] + "Operation"
+	b.WriteString(fmt.Sprintf("\nconst %s = %s\n", queryConst, strconv.Quote(query.String())))
+
+	varsType := ""
+	if 0 < len(op.Variables) {
+		varsType = public + "Variables"
+		g.names[varsType] = true
+		b.WriteString(fmt.Sprintf("\n// %s are the variables for the %s %s.\n", varsType, public, op.Type))
+		b.WriteString(fmt.Sprintf("type %s struct {\n", varsType))
+		for _, vd := range op.Variables {
+			b.WriteString(fmt.Sprintf("\t%s %s `json:\"%s", publicName(vd.Name), g.inType(vd.Type), vd.Name))
+			if _, ok := vd.Type.(*ggql.NonNull); !ok {
+				b.WriteString(",omitempty")
+			}
+			b.WriteString("\"`\n")
+		}
+		b.WriteString("}\n")
+	}
+	respType := g.uniqueName(public + "Response")
+	if err = g.genStruct(respType, fmt.Sprintf("is the response data for the %s %s.", public, op.Type),
+		opType.Type, op.Sels); err != nil {
+		return
+	}
+	b.WriteString(fmt.Sprintf("\n// %s sends the %s %s and returns the response data.\n", public, public, op.Type))
+	b.WriteString("// Errors returned by the server are returned as an Errors along with any\n// partial data.\n")
+	if 0 < len(varsType) {
+		b.WriteString(fmt.Sprintf("func (c *Client) %s(ctx context.Context, vars *%s) (*%s, error) {\n", public, varsType, respType))
+	} else {
+		b.WriteString(fmt.Sprintf("func (c *Client) %s(ctx context.Context) (*%s, error) {\n", public, respType))
+		b.WriteString("\tvar vars map[string]interface{}\n")
+	}
+	b.WriteString(fmt.Sprintf("\tvar data %s\n", respType))
+	b.WriteString(fmt.Sprintf("\terr := c.Do(ctx, %s, %q, vars, &data)\n\n", queryConst, op.Name))
+	b.WriteString("\treturn &data, err\n}\n")
+
+	return
+}
+
+// addTypename adds a __typename field to the selections of interface and
+// union field types so the response can be decoded into the matching
+// struct. Fragment selections are already covered by the selections of the
+// field they are in. The fragments referenced are collected in frags.
+func (g *clientGen) addTypename(
+	t ggql.Type,
+	sels []ggql.Selection,
+	frags map[string]*ggql.Fragment,
+	inFrag bool) []ggql.Selection {
+
+	t = ggql.BaseType(t)
+	switch t.(type) {
+	case *ggql.Interface, *ggql.Union:
+		if inFrag {
+			break
+		}
+		found := false
+		for _, sel := range sels {
+			if f, ok := sel.(*ggql.Field); ok && f.Name == typenameStr && len(f.Alias) == 0 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			sels = append([]ggql.Selection{&ggql.Field{Name: typenameStr}}, sels...)
+		}
+	}
+	for _, sel := range sels {
+		switch ts := sel.(type) {
+		case *ggql.Field:
+			if fd := getFieldDef(t, ts.Name); fd != nil {
+				ts.Sels = g.addTypename(fd.Type, ts.Sels, frags, false)
+			}
+		case *ggql.Inline:
+			ct := t
+			if ts.Condition != nil {
+				ct = ts.Condition
+			}
+			ts.Sels = g.addTypename(ct, ts.Sels, frags, true)
+		case *ggql.FragRef:
+			if f := ts.Fragment; f != nil && frags[f.Name] == nil {
+				frags[f.Name] = f
+				f.Sels = g.addTypename(f.Condition, f.Sels, frags, true)
+			}
+		}
+	}
+	return sels
+}
+
+func getFieldDef(t ggql.Type, name string) (fd *ggql.FieldDef) {
+	switch tt := ggql.BaseType(t).(type) {
+	case *ggql.Object:
+		fd = tt.GetField(name)
+	case *ggql.Interface:
+		fd = tt.GetField(name)
+	}
+	return
+}
+
+// implements returns true if t is the same type as cond or is an object or
+// interface that implements cond.
+func implements(t, cond ggql.Type) bool {
+	if t.Name() == cond.Name() {
+		return true
+	}
+	var list []ggql.Type
+	switch tt := t.(type) {
+	case *ggql.Object:
+		list = tt.Interfaces
+	case *ggql.Interface:
+		list = tt.Interfaces
+	}
+	for _, i := range list {
+		if implements(i, cond) {
+			return true
+		}
+	}
+	return false
+}
+
+// possibleObjects returns the names of the object types that could match a
+// fragment with a type condition of t.
+func (g *clientGen) possibleObjects(t ggql.Type) (names []string) {
+	switch tt := t.(type) {
+	case *ggql.Object:
+		names = append(names, tt.Name())
+	case *ggql.Union:
+		for _, m := range tt.Members {
+			names = append(names, m.Name())
+		}
+	case *ggql.Interface:
+		for _, x := range g.root.Types() {
+			if obj, ok := x.(*ggql.Object); ok && implements(obj, tt) {
+				names = append(names, obj.Name())
+			}
+		}
+	}
+	sort.Strings(names)
+	return
+}
+
+// collect the fields in the selections that apply to type t. Fragments
+// that only apply to some of the possible types of t are added to conds.
+func (g *clientGen) collect(
+	t ggql.Type,
+	sels []ggql.Selection,
+	fields *[]*selField,
+	conds *[]*condSels) error {
+
+	for _, sel := range sels {
+		var cond ggql.Type
+		var fsels []ggql.Selection
+		switch ts := sel.(type) {
+		case *ggql.Field:
+			key := ts.Name
+			if 0 < len(ts.Alias) {
+				key = ts.Alias
+			}
+			var fd *ggql.FieldDef
+			if ts.Name == typenameStr {
+				fd = &ggql.FieldDef{Base: ggql.Base{N: typenameStr}, Type: &ggql.NonNull{Base: g.root.GetType("String")}}
+			} else if fd = getFieldDef(t, ts.Name); fd == nil {
+				return fmt.Errorf("%s is not a field of %s at %d:%d", ts.Name, t.Name(), ts.Line(), ts.Column())
+			}
+			merged := false
+			for _, sf := range *fields {
+				if sf.key == key {
+					sf.sels = append(sf.sels, ts.Sels...)
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				*fields = append(*fields, &selField{key: key, fd: fd, sels: ts.Sels})
+			}
+			continue
+		case *ggql.Inline:
+			cond = ts.Condition
+			fsels = ts.Sels
+		case *ggql.FragRef:
+			cond = ts.Fragment.Condition
+			fsels = ts.Fragment.Sels
+		}
+		switch {
+		case cond == nil || implements(t, cond):
+			if err := g.collect(t, fsels, fields, conds); err != nil {
+				return err
+			}
+		case conds != nil:
+			*conds = append(*conds, &condSels{cond: cond, sels: fsels})
+		}
+	}
+	return nil
+}
+
+func (g *clientGen) uniqueName(name string) string {
+	base := name
+	for i := 2; g.names[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	g.names[name] = true
+	return name
+}
+
+// genStruct generates a struct named name for the selections on type t.
+func (g *clientGen) genStruct(name, desc string, t ggql.Type, sels []ggql.Selection) error {
+	t = ggql.BaseType(t)
+	var fields []*selField
+	var conds []*condSels
+	if err := g.collect(t, sels, &fields, &conds); err != nil {
+		return err
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\n// %s %s\n", name, desc))
+	b.WriteString(fmt.Sprintf("type %s struct {\n", name))
+	elems := map[*selField]string{}
+	for _, sf := range fields {
+		goName := publicName(sf.key)
+		if sf.key == typenameStr {
+			goName = "Typename"
+		}
+		if 0 < len(sf.sels) {
+			elems[sf] = g.uniqueName(name + goName)
+		}
+		b.WriteString(fmt.Sprintf("\t%s %s `json:\"%s\"`\n", goName, g.outType(sf.fd.Type, elems[sf]), sf.key))
+	}
+	var objNames []string
+	objSels := map[string][]ggql.Selection{}
+	for _, c := range conds {
+		for _, on := range g.possibleObjects(c.cond) {
+			if _, has := objSels[on]; !has {
+				objNames = append(objNames, on)
+			}
+			objSels[on] = append(objSels[on], c.sels...)
+		}
+	}
+	sort.Strings(objNames)
+	structs := map[string]string{}
+	if 0 < len(objNames) {
+		b.WriteString("\n")
+	}
+	for _, on := range objNames {
+		sn := g.uniqueName(name + on)
+		structs[on] = sn
+		b.WriteString(fmt.Sprintf("\t// %s is set for %s values of the %s.\n", on, on, t.Name()))
+		b.WriteString(fmt.Sprintf("\t%s *%s `json:\"-\"`\n", on, sn))
+	}
+	b.WriteString("}\n")
+	if 0 < len(objNames) {
+		b.WriteString(fmt.Sprintf("\n// UnmarshalJSON decodes the data and the fields of the %s fragments.\n", t.Name()))
+		b.WriteString(fmt.Sprintf("func (x *%s) UnmarshalJSON(data []byte) (err error) {\n", name))
+		b.WriteString(fmt.Sprintf("\ttype plain %s\n", name))
+		b.WriteString("\tif err = json.Unmarshal(data, (*plain)(x)); err != nil {\n\t\treturn\n\t}\n")
+		b.WriteString("\tswitch x.Typename {\n")
+		for _, on := range objNames {
+			b.WriteString(fmt.Sprintf("\tcase %q:\n", on))
+			b.WriteString(fmt.Sprintf("\t\tx.%s = &%s{}\n", on, structs[on]))
+			b.WriteString(fmt.Sprintf("\t\terr = json.Unmarshal(data, x.%s)\n", on))
+		}
+		b.WriteString("\t}\n\treturn\n}\n")
+	}
+	g.types.WriteString(b.String())
+
+	for _, sf := range fields {
+		if elem := elems[sf]; 0 < len(elem) {
+			if err := g.genStruct(elem, fmt.Sprintf("is the %s field of %s.", sf.key, name),
+				sf.fd.Type, sf.sels); err != nil {
+				return err
+			}
+		}
+	}
+	for _, on := range objNames {
+		if err := g.genStruct(structs[on], fmt.Sprintf("has the fields of %s for %s values.", name, on),
+			g.root.GetType(on), objSels[on]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// outType returns the Go type for a response field. The elem is the name of
+// the struct for object, interface, and union types.
+func (g *clientGen) outType(t ggql.Type, elem string) string {
+	nonNull := false
+	if nn, ok := t.(*ggql.NonNull); ok {
+		t = nn.Base
+		nonNull = true
+	}
+	if list, ok := t.(*ggql.List); ok {
+		return "[]" + g.outType(list.Base, elem)
+	}
+	if len(elem) == 0 {
+		elem = g.scalarType(t)
+	}
+	if nonNull || elem == "interface{}" {
+		return elem
+	}
+	return "*" + elem
+}
+
+// inType returns the Go type for a variable or input field.
+func (g *clientGen) inType(t ggql.Type) string {
+	elem := ""
+	if in, ok := ggql.BaseType(t).(*ggql.Input); ok {
+		g.inputs[in.Name()] = in
+		elem = in.Name()
+	}
+	return g.outType(t, elem)
+}
+
+func (g *clientGen) scalarType(t ggql.Type) string {
+	switch t.Name() {
+	case "String", "ID":
+		return "string"
+	case "Int":
+		return "int"
+	case "Float":
+		return "float64"
+	case "Boolean":
+		return "bool"
+	case "Int64":
+		return "int64"
+	case timeStr:
+		g.imports["time"] = true
+		return "time.Time"
+	}
+	if e, ok := t.(*ggql.Enum); ok {
+		g.enums[e.Name()] = e
+		return e.Name()
+	}
+	return "interface{}"
+}
+
+func (g *clientGen) genInputs() error {
+	done := map[string]bool{}
+	for 0 < len(g.inputs) {
+		names := make([]string, 0, len(g.inputs))
+		for name := range g.inputs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			in := g.inputs[name]
+			delete(g.inputs, name)
+			if done[name] {
+				continue
+			}
+			done[name] = true
+			if g.names[name] {
+				return fmt.Errorf("input type %s conflicts with a generated type of the same name", name)
+			}
+			g.names[name] = true
+			g.types.WriteString(fmt.Sprintf("\n// %s is the %s input type.\n", name, name))
+			g.types.WriteString(fmt.Sprintf("type %s struct {\n", name))
+			for _, f := range in.Fields() {
+				g.types.WriteString(fmt.Sprintf("\t%s %s `json:\"%s", publicName(f.Name()), g.inType(f.Type), f.Name()))
+				if _, ok := f.Type.(*ggql.NonNull); !ok {
+					g.types.WriteString(",omitempty")
+				}
+				g.types.WriteString("\"`\n")
+			}
+			g.types.WriteString("}\n")
+		}
+	}
+	g.genEnums()
+
+	return nil
+}
+
+func (g *clientGen) genEnums() {
+	names := make([]string, 0, len(g.enums))
+	for name := range g.enums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		e := g.enums[name]
+		delete(g.enums, name)
+		if g.names[name] {
+			continue
+		}
+		g.names[name] = true
+		g.types.WriteString(fmt.Sprintf("\n// %s is the %s enum type.\n", name, name))
+		g.types.WriteString(fmt.Sprintf("type %s string\n\n// Values of %s.\nconst (\n", name, name))
+		for _, ev := range e.Values() {
+			var cn strings.Builder
+			for _, part := range strings.Split(strings.ToLower(string(ev.Value)), "_") {
+				if 0 < len(part) {
+					cn.WriteString(strings.ToUpper(part[:1]) + part[1:])
+				}
+			}
+			g.types.WriteString(fmt.Sprintf("\t%s%s %s = %q\n", name, cn.String(), name, ev.Value))
+		}
+		g.types.WriteString(")\n")
+	}
+}
+
+// clientSupport is the code shared by all the generated operations.
+const clientSupport = `
+// Doer sends an HTTP request. An *http.Client is a Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client sends GraphQL operations to a server.
+type Client struct {
+	// URL of the GraphQL endpoint.
+	URL string
+
+	// Doer sends the requests. If nil http.DefaultClient is used.
+	Doer Doer
+
+	// Header values to add to each request.
+	Header http.Header
+}
+
+// Location of an error in an operation.
+type Location struct {
+	Line   int ` + "`json:\"line\"`" + `
+	Column int ` + "`json:\"column\"`" + `
+}
+
+// Error is a GraphQL error returned by the server.
+type Error struct {
+	Message    string                 ` + "`json:\"message\"`" + `
+	Locations  []Location             ` + "`json:\"locations,omitempty\"`" + `
+	Path       []interface{}          ` + "`json:\"path,omitempty\"`" + `
+	Extensions map[string]interface{} ` + "`json:\"extensions,omitempty\"`" + `
+}
+
+// Error returns the error message.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Errors is a list of GraphQL errors returned by the server.
+type Errors []*Error
+
+// Error returns the error messages.
+func (ea Errors) Error() string {
+	msgs := make([]string, len(ea))
+	for i, e := range ea {
+		msgs[i] = e.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Do sends an operation to the server and decodes the response data into
+// data. If the server returns errors they are returned as an Errors.
+func (c *Client) Do(ctx context.Context, query, opName string, vars interface{}, data interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":         query,
+		"operationName": opName,
+		"variables":     vars,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range c.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	doer := c.Doer
+	if doer == nil {
+		doer = http.DefaultClient
+	}
+	res, err := doer.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	var result struct {
+		Data   json.RawMessage ` + "`json:\"data\"`" + `
+		Errors Errors          ` + "`json:\"errors\"`" + `
+	}
+	if err = json.NewDecoder(res.Body).Decode(&result); err != nil {
+		if res.StatusCode != http.StatusOK {
+			return fmt.Errorf("%s", res.Status)
+		}
+		return err
+	}
+	if 0 < len(result.Data) && string(result.Data) != "null" {
+		if err = json.Unmarshal(result.Data, data); err != nil {
+			return err
+		}
+	}
+	if 0 < len(result.Errors) {
+		return result.Errors
+	}
+	return nil
+}
+`
@@ -0,0 +1,96 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const clientSDL = `
+enum Genre { POP ROCK }
+scalar Time
+interface Named { name: String! }
+type Artist implements Named { name: String! songs: [Song!]! }
+type Song implements Named { name: String! genre: Genre when: Time }
+union Any = Artist | Song
+input SongFilter { genre: Genre name: String range: Range }
+input Range { low: Int high: Int = 9 }
+type Query {
+  artist(name: String!): Artist
+  named: [Named]
+  any(filter: SongFilter): [Any]
+}
+type Mutation { like(song: String!): Int }
+`
+
+const clientOps = `
+query Artist($name: String!) { artist(name: $name) { name songs { ...SongInfo } } }
+query Everything($filter: SongFilter) {
+  named { name ... on Song { genre } }
+  any(filter: $filter) { ... on Artist { name } ...SongInfo }
+}
+query Quoted { artist(name: "back` + "`" + `tick") { name } }
+mutation Like($song: String!) { like(song: $song) }
+fragment SongInfo on Song { name genre when }
+`
+
+func TestClientCompile(t *testing.T) {
+	g := newClientGen()
+	if err := g.root.ParseString(clientSDL); err != nil {
+		t.Fatalf("parse failed. %s", err)
+	}
+	src, err := g.generate("client", []byte(clientOps))
+	if err != nil {
+		t.Fatalf("generate failed. %s", err)
+	}
+	for _, expect := range []string{
+		"func (c *Client) Artist(ctx context.Context, vars *ArtistVariables) (*ArtistResponse, error)",
+		"func (c *Client) Everything(ctx context.Context, vars *EverythingVariables) (*EverythingResponse, error)",
+		"func (c *Client) Like(ctx context.Context, vars *LikeVariables) (*LikeResponse, error)",
+		"func (c *Client) Quoted(ctx context.Context) (*QuotedResponse, error)",
+		"type EverythingResponseAnyArtist struct",
+		"type EverythingResponseNamedSong struct",
+		"type Genre string",
+		"type SongFilter struct",
+		"type Range struct",
+		"back`tick",
+	} {
+		if !strings.Contains(string(src), expect) {
+			t.Errorf("generated client does not contain %q\n%s", expect, src)
+		}
+	}
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	dir := t.TempDir()
+	if err = ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module client\n\ngo 1.16\n"), 0600); err != nil {
+		t.Fatalf("write failed. %s", err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, "client.go"), src, 0600); err != nil {
+		t.Fatalf("write failed. %s", err)
+	}
+	cmd := exec.Command(goCmd, "vet", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go vet of the generated client failed. %s\n%s\n%s", err, out, src)
+	}
+}
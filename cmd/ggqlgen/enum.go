@@ -16,7 +16,6 @@ package main
 
 import (
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
 	"strings"
 
@@ -29,21 +28,26 @@ func stubEnum(t *ggql.Enum) (err error) {
 	b.WriteString(fmt.Sprintf("package %s\n\n", pkg))
 	b.WriteString("const (\n")
 	for _, ev := range t.Values() {
-		name := t.Name() + string(ev.Value)
-		desc := ev.Description
-		if len(desc) == 0 {
-			desc = dotdotdot
-		}
-		if strings.HasPrefix(desc, name+" ") {
-			b.WriteString(fmt.Sprintf("\t// %s\n", strings.ReplaceAll(desc, "\n", "\n\t// ")))
-		} else {
-			b.WriteString(fmt.Sprintf("\t// %s %s\n", name, strings.ReplaceAll(desc, "\n", "\n\t// ")))
-		}
-		b.WriteString(fmt.Sprintf("\t%s = %q\n", name, string(ev.Value)))
+		stubEnumValue(&b, t, ev)
 	}
 	b.WriteString(")\n")
 
 	path := filepath.Join(stubDir, strings.ToLower(t.Name())+".go")
 
-	return ioutil.WriteFile(path, []byte(b.String()), 0600)
+	return writeStub(path, b.String(), func(sf *stubFile) { sf.mergeEnum(t) })
+}
+
+// stubEnumValue writes a constant for an enum value.
+func stubEnumValue(b *strings.Builder, t *ggql.Enum, ev *ggql.EnumValue) {
+	name := t.Name() + string(ev.Value)
+	desc := ev.Description
+	if len(desc) == 0 {
+		desc = dotdotdot
+	}
+	if strings.HasPrefix(desc, name+" ") {
+		b.WriteString(fmt.Sprintf("\t// %s\n", strings.ReplaceAll(desc, "\n", "\n\t// ")))
+	} else {
+		b.WriteString(fmt.Sprintf("\t// %s %s\n", name, strings.ReplaceAll(desc, "\n", "\n\t// ")))
+	}
+	b.WriteString(fmt.Sprintf("\t%s = %q\n", name, string(ev.Value)))
 }
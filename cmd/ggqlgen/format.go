@@ -0,0 +1,146 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// formatMain runs the fmt sub-command and returns the exit code.
+func formatMain(args []string) int {
+	fs := flag.NewFlagSet("fmt", flag.ExitOnError)
+	write := fs.Bool("w", false, "write the result to the file instead of stdout")
+	list := fs.Bool("l", false, "list files whose formatting differs")
+	indent := fs.Int("indent", 2, "number of spaces to indent operation files by")
+	sorted := fs.Bool("sort", false, "sort operations, fragments, selections, and arguments in operation files")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `
+ggqlgen fmt formats GraphQL files. Operation files, those with queries,
+mutations, subscriptions, and fragments, are formatted with comments kept
+and do not need a schema. Schema files are formatted the same way as with
+the -w option of ggqlgen. If no files are given the input is read from stdin
+and written to stdout.
+
+Usage: ggqlgen fmt [options] [<graphql-file>...]
+
+`)
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	opts := ggql.FormatOptions{Indent: *indent, Sort: *sorted}
+	if len(fs.Args()) == 0 {
+		src, err := ioutil.ReadAll(os.Stdin)
+		if err == nil {
+			src, err = formatGraphQL(src, &opts)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to format stdin: %s\n", err)
+			return 1
+		}
+		_, _ = os.Stdout.Write(src)
+		return 0
+	}
+	code := 0
+	for _, path := range fs.Args() {
+		src, err := ioutil.ReadFile(path)
+		var out []byte
+		if err == nil {
+			out, err = formatGraphQL(src, &opts)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to format %s: %s\n", path, err)
+			code = 1
+			continue
+		}
+		if *list && !bytes.Equal(src, out) {
+			fmt.Println(path)
+		}
+		switch {
+		case *write:
+			if !bytes.Equal(src, out) {
+				if err = ioutil.WriteFile(path, out, 0600); err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to write %s: %s\n", path, err)
+					code = 1
+				}
+			}
+		case !*list:
+			_, _ = os.Stdout.Write(out)
+		}
+	}
+	return code
+}
+
+// formatGraphQL formats src as an executable document if it starts with an
+// operation or fragment and as a schema otherwise.
+func formatGraphQL(src []byte, opts *ggql.FormatOptions) ([]byte, error) {
+	if isExecutable(src) {
+		return ggql.FormatExecutable(src, opts)
+	}
+	return formatSDL(src)
+}
+
+func isExecutable(src []byte) bool {
+	for i := 0; i < len(src); i++ {
+		switch src[i] {
+		case ' ', '\t', '\r', '\n', ',':
+			continue
+		case '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			continue
+		case '{':
+			return true
+		}
+		for _, kw := range []string{"query", "mutation", "subscription", "fragment"} {
+			if bytes.HasPrefix(src[i:], []byte(kw)) {
+				end := i + len(kw)
+				if len(src) <= end || !isNameByte(src[end]) {
+					return true
+				}
+			}
+		}
+		break
+	}
+	return false
+}
+
+func isNameByte(b byte) bool {
+	return b == '_' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9'
+}
+
+// formatSDL formats a schema by parsing it and writing it back out. Types
+// such as Time that are in every root are left out unless changed.
+func formatSDL(src []byte) ([]byte, error) {
+	root := ggql.NewRoot(nil)
+	if err := root.Parse(src); err != nil {
+		return nil, err
+	}
+	sdl := root.SDL(false, true)
+	for _, t := range ggql.NewRoot(nil).Types() {
+		if !t.Core() {
+			sdl = strings.Replace(sdl, "\n"+t.SDL(true), "", 1)
+		}
+	}
+	return []byte(strings.TrimPrefix(sdl, "\n")), nil
+}
@@ -16,7 +16,6 @@ package main
 
 import (
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
 	"strings"
 
@@ -33,31 +32,13 @@ func stubInput(t *ggql.Input) (err error) {
 			break
 		}
 	}
-	desc := t.Description()
-	if len(desc) == 0 {
-		desc = dotdotdot
-	}
-	if strings.HasPrefix(desc, t.Name()+" ") {
-		b.WriteString(fmt.Sprintf("// %s\n", strings.ReplaceAll(desc, "\n", "\n// ")))
-	} else {
-		b.WriteString(fmt.Sprintf("// %s %s\n", t.Name(), strings.ReplaceAll(desc, "\n", "\n// ")))
-	}
+	typeComment(&b, t.Name(), t.Description())
 	b.WriteString(fmt.Sprintf("type %s struct {\n", t.Name()))
 	for _, f := range t.Fields() {
-		public := publicName(f.N)
-		desc = f.Desc
-		if len(desc) == 0 {
-			desc = dotdotdot
-		}
-		if strings.HasPrefix(desc, public+" ") {
-			b.WriteString(fmt.Sprintf("\n\t// %s\n", strings.ReplaceAll(desc, "\n", "\n\t// ")))
-		} else {
-			b.WriteString(fmt.Sprintf("\n\t// %s %s\n", public, strings.ReplaceAll(desc, "\n", "\n\t// ")))
-		}
-		b.WriteString(fmt.Sprintf("\t%s %s\n", public, typeStr(f.Type)))
+		stubStructField(&b, f.N, f.Desc, f.Type)
 	}
 	b.WriteString("}\n")
 	path := filepath.Join(stubDir, strings.ToLower(t.Name())+".go")
 
-	return ioutil.WriteFile(path, []byte(b.String()), 0600)
+	return writeStub(path, b.String(), func(sf *stubFile) { sf.mergeInput(t) })
 }
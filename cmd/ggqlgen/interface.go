@@ -0,0 +1,89 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// stubInterface writes a Go interface for a GraphQL interface along with a
+// marker method for each object that implements the interface. Interfaces
+// that the interface implements are embedded.
+func stubInterface(root *ggql.Root, t *ggql.Interface) (err error) {
+	var parents []string
+	for _, it := range t.Interfaces {
+		parents = append(parents, it.Name())
+	}
+	var impls []string
+	for _, rt := range root.Types() {
+		if o, ok := rt.(*ggql.Object); ok && !o.Core() {
+			for _, it := range o.Interfaces {
+				if it.Name() == t.Name() {
+					impls = append(impls, o.Name())
+					break
+				}
+			}
+		}
+	}
+	return stubAbstract(t.Name(), t.Description(), parents, impls)
+}
+
+// stubAbstract writes the stub file for an interface or union. Objects are
+// tied to the Go interface with a marker method so that objects can be
+// assigned to fields of the interface or union type.
+func stubAbstract(name, desc string, parents, impls []string) error {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("package %s\n\n", pkg))
+	if !reflect {
+		b.WriteString("import \"github.com/uhn/ggql/pkg/ggql\"\n\n")
+	}
+	stubAbstractDecl(&b, name, desc, parents)
+	for _, impl := range impls {
+		b.WriteString("\n")
+		stubMarker(&b, impl, name)
+	}
+	path := filepath.Join(stubDir, strings.ToLower(name)+".go")
+
+	return writeStub(path, b.String(), func(sf *stubFile) { sf.mergeAbstract(name, desc, parents, impls) })
+}
+
+func stubAbstractDecl(b *strings.Builder, name, desc string, parents []string) {
+	typeComment(b, name, desc)
+	b.WriteString(fmt.Sprintf("type %s interface {\n", name))
+	if !reflect {
+		b.WriteString("\tggql.Resolver\n")
+	}
+	for _, p := range parents {
+		b.WriteString(fmt.Sprintf("\t%s\n", p))
+	}
+	b.WriteString(fmt.Sprintf("\n\t%s()\n", markerName(name)))
+	b.WriteString("}\n")
+}
+
+// stubMarker writes the marker method that ties an object to an interface
+// or union.
+func stubMarker(b *strings.Builder, recv, name string) {
+	b.WriteString(fmt.Sprintf("// %s marks %s as a %s.\n", markerName(name), recv, name))
+	b.WriteString(fmt.Sprintf("func (*%s) %s() {}\n", recv, markerName(name)))
+}
+
+func markerName(name string) string {
+	return "is" + name
+}
@@ -0,0 +1,387 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+var (
+	pascalCase    = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	camelCase     = regexp.MustCompile(`^[a-z][A-Za-z0-9]*$`)
+	screamingCase = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+)
+
+const defaultDeprecationReason = `"No longer supported"`
+
+// lintRules are the names and descriptions of the lint rules.
+var lintRules = [][2]string{
+	{"description", "types and fields must have a description"},
+	{"type-case", "type names must be PascalCase"},
+	{"field-case", "field and argument names must be camelCase"},
+	{"enum-case", "enum values must be SCREAMING_CASE"},
+	{"nullable-list-item", "list items in field types must be non-null"},
+	{"unused-type", "types must be reachable from the schema"},
+	{"type-suffix", "input type names and only input type names end with the input suffix"},
+	{"deprecated-reason", "@deprecated must include a reason"},
+}
+
+// lintIssue is a single lint rule violation.
+type lintIssue struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+}
+
+// lintFile is used to map a line in the combined SDL to a file.
+type lintFile struct {
+	path  string
+	start int
+}
+
+type linter struct {
+	root        *ggql.Root
+	builtin     map[string]bool
+	rules       map[string]bool
+	inputSuffix string
+	files       []*lintFile
+	issues      []*lintIssue
+}
+
+// lintMain runs the lint sub-command and returns the exit code. The exit
+// code is 1 if any issues are found.
+func lintMain(args []string) int {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	rules := fs.String("rules", "", "comma separated list of rules to check. All rules are checked if empty")
+	skip := fs.String("skip", "", "comma separated list of rules to skip")
+	suffix := fs.String("input-suffix", "Input", "suffix for input type names")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `
+ggqlgen lint checks a GraphQL schema against a set of rules and writes the
+issues found as a JSON array to stdout. Each issue includes the rule, a
+message, and the file, line, and column of the offending definition. The exit
+code is 1 if any issues are found. The rules are:
+
+`)
+		for _, r := range lintRules {
+			fmt.Fprintf(os.Stderr, "  %-20s %s\n", r[0], r[1])
+		}
+		fmt.Fprintf(os.Stderr, "\nUsage: ggqlgen lint [options] [<schema-file>...]\n\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	l, err := newLinter(splitList(*rules), splitList(*skip), *suffix)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 2
+	}
+	if err = l.parse(fs.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 2
+	}
+	l.lint()
+
+	j, _ := json.MarshalIndent(l.issues, "", "  ")
+	fmt.Println(string(j))
+	if 0 < len(l.issues) {
+		return 1
+	}
+	return 0
+}
+
+// newLinter creates a linter that checks the rules given or all rules if
+// none are given less the rules to skip.
+func newLinter(rules, skip []string, inputSuffix string) (*linter, error) {
+	l := linter{
+		root:        ggql.NewRoot(nil),
+		builtin:     map[string]bool{},
+		rules:       map[string]bool{},
+		inputSuffix: inputSuffix,
+		issues:      []*lintIssue{},
+	}
+	for _, t := range l.root.Types() {
+		l.builtin[t.Name()] = true
+	}
+	for _, r := range lintRules {
+		l.rules[r[0]] = len(rules) == 0
+	}
+	for _, name := range rules {
+		if _, has := l.rules[name]; !has {
+			return nil, fmt.Errorf("unknown lint rule %s", name)
+		}
+		l.rules[name] = true
+	}
+	for _, name := range skip {
+		delete(l.rules, name)
+	}
+	return &l, nil
+}
+
+func splitList(s string) (list []string) {
+	for _, x := range strings.Split(s, ",") {
+		if x = strings.TrimSpace(x); 0 < len(x) {
+			list = append(list, x)
+		}
+	}
+	return
+}
+
+// parse all the files as one SDL document so the order of the files does
+// not matter while keeping track of the line each file starts on.
+func (l *linter) parse(paths []string) (err error) {
+	var buf []byte
+	if len(paths) == 0 {
+		if buf, err = ioutil.ReadAll(os.Stdin); err != nil {
+			return
+		}
+		l.files = append(l.files, &lintFile{start: 1})
+	}
+	line := 1
+	for _, path := range paths {
+		var sdl []byte
+		if sdl, err = getSDL(path); err != nil {
+			return fmt.Errorf("failed to read schema file %s: %w", path, err)
+		}
+		if 0 < len(sdl) && sdl[len(sdl)-1] != '\n' {
+			sdl = append(sdl, '\n')
+		}
+		l.files = append(l.files, &lintFile{path: path, start: line})
+		line += strings.Count(string(sdl), "\n")
+		buf = append(buf, sdl...)
+	}
+	if err = l.root.Parse(buf); err != nil {
+		err = fmt.Errorf("failed to parse schema: %w", err)
+	}
+	return
+}
+
+func (l *linter) lint() {
+	for _, t := range l.root.Types() {
+		if _, ok := t.(*ggql.Schema); ok || l.builtin[t.Name()] {
+			continue
+		}
+		l.lintType(t)
+	}
+	if l.rules["unused-type"] {
+		l.lintUnused()
+	}
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		ii := l.issues[i]
+		ij := l.issues[j]
+		if ii.File != ij.File {
+			return ii.File < ij.File
+		}
+		if ii.Line != ij.Line {
+			return ii.Line < ij.Line
+		}
+		return ii.Column < ij.Column
+	})
+}
+
+type located interface {
+	Line() int
+	Column() int
+}
+
+func (l *linter) report(rule string, at located, format string, args ...interface{}) {
+	if !l.rules[rule] {
+		return
+	}
+	issue := lintIssue{
+		Rule:    rule,
+		Message: fmt.Sprintf(format, args...),
+		Line:    at.Line(),
+		Column:  at.Column(),
+	}
+	for _, f := range l.files {
+		if f.start <= issue.Line {
+			issue.File = f.path
+			issue.Line = at.Line() - f.start + 1
+		}
+	}
+	l.issues = append(l.issues, &issue)
+}
+
+func (l *linter) lintType(t ggql.Type) {
+	if len(t.Description()) == 0 {
+		l.report("description", t, "type %s has no description", t.Name())
+	}
+	if !pascalCase.MatchString(t.Name()) {
+		l.report("type-case", t, "type %s is not PascalCase", t.Name())
+	}
+	if 0 < len(l.inputSuffix) {
+		_, isInput := t.(*ggql.Input)
+		hasSuffix := strings.HasSuffix(t.Name(), l.inputSuffix)
+		switch {
+		case isInput && !hasSuffix:
+			l.report("type-suffix", t, "input type %s does not end with %s", t.Name(), l.inputSuffix)
+		case !isInput && hasSuffix:
+			l.report("type-suffix", t, "type %s ends with %s but is not an input type", t.Name(), l.inputSuffix)
+		}
+	}
+	switch tt := t.(type) {
+	case *ggql.Object:
+		l.lintFields(tt.Name(), tt.Fields())
+	case *ggql.Interface:
+		l.lintFields(tt.Name(), tt.Fields())
+	case *ggql.Input:
+		for _, f := range tt.Fields() {
+			l.lintField(tt.Name(), f.Name(), &f.Base)
+		}
+	case *ggql.Enum:
+		for _, ev := range tt.Values() {
+			if !screamingCase.MatchString(string(ev.Value)) {
+				l.report("enum-case", ev, "enum value %s of %s is not SCREAMING_CASE", ev.Value, tt.Name())
+			}
+			l.lintDeprecated(ev, ev.Directives, "enum value %s of %s", ev.Value, tt.Name())
+		}
+	}
+}
+
+func (l *linter) lintFields(typeName string, fields []*ggql.FieldDef) {
+	for _, fd := range fields {
+		l.lintField(typeName, fd.Name(), &fd.Base)
+		for ft := fd.Type; ft != nil; {
+			switch tt := ft.(type) {
+			case *ggql.NonNull:
+				ft = tt.Base
+			case *ggql.List:
+				if _, ok := tt.Base.(*ggql.NonNull); !ok {
+					l.report("nullable-list-item", fd, "field %s of %s has nullable list items", fd.Name(), typeName)
+				}
+				ft = tt.Base
+			default:
+				ft = nil
+			}
+		}
+		for _, a := range fd.Args() {
+			if !camelCase.MatchString(a.Name()) {
+				l.report("field-case", a, "argument %s of %s.%s is not camelCase", a.Name(), typeName, fd.Name())
+			}
+			l.lintDeprecated(a, a.Dirs, "argument %s of %s.%s", a.Name(), typeName, fd.Name())
+		}
+	}
+}
+
+func (l *linter) lintField(typeName, name string, b *ggql.Base) {
+	if len(b.Desc) == 0 {
+		l.report("description", b, "field %s of %s has no description", name, typeName)
+	}
+	if !camelCase.MatchString(name) {
+		l.report("field-case", b, "field %s of %s is not camelCase", name, typeName)
+	}
+	l.lintDeprecated(b, b.Dirs, "field %s of %s", name, typeName)
+}
+
+func (l *linter) lintDeprecated(at located, dirs []*ggql.DirectiveUse, format string, args ...interface{}) {
+	for _, du := range dirs {
+		if du.Directive.Name() != "deprecated" {
+			continue
+		}
+		// The parser fills in the default reason when one is not given.
+		var reason string
+		if av := du.Args["reason"]; av != nil {
+			reason, _ = av.Value.(string)
+		}
+		if len(reason) == 0 || reason == defaultDeprecationReason {
+			l.report("deprecated-reason", at, format+" is deprecated without a reason", args...)
+		}
+	}
+}
+
+// lintUnused reports types that can not be reached from the schema.
+func (l *linter) lintUnused() {
+	used := map[string]bool{}
+	var mark func(t ggql.Type)
+	mark = func(t ggql.Type) {
+		t = ggql.BaseType(t)
+		if t == nil || used[t.Name()] {
+			return
+		}
+		used[t.Name()] = true
+		var fields []*ggql.FieldDef
+		switch tt := t.(type) {
+		case *ggql.Object:
+			fields = tt.Fields()
+			for _, i := range tt.Interfaces {
+				mark(i)
+			}
+		case *ggql.Interface:
+			fields = tt.Fields()
+			for _, i := range tt.Interfaces {
+				mark(i)
+			}
+			for _, x := range l.root.Types() {
+				if obj, ok := x.(*ggql.Object); ok && hasInterface(obj.Interfaces, tt) {
+					mark(obj)
+				}
+			}
+		case *ggql.Union:
+			for _, m := range tt.Members {
+				mark(m)
+			}
+		case *ggql.Input:
+			for _, f := range tt.Fields() {
+				mark(f.Type)
+			}
+		}
+		for _, fd := range fields {
+			mark(fd.Type)
+			for _, a := range fd.Args() {
+				mark(a.Type)
+			}
+		}
+	}
+	if schema := l.root.Schema(); schema != nil {
+		for _, fd := range schema.Fields() {
+			mark(fd.Type)
+		}
+	}
+	for _, name := range []string{"Query", "Mutation", "Subscription"} {
+		if t := l.root.GetType(name); t != nil {
+			mark(t)
+		}
+	}
+	for _, t := range l.root.Types() {
+		if _, ok := t.(*ggql.Schema); ok || l.builtin[t.Name()] || used[t.Name()] {
+			continue
+		}
+		l.report("unused-type", t, "type %s is not used", t.Name())
+	}
+}
+
+func hasInterface(list []ggql.Type, t ggql.Type) bool {
+	for _, x := range list {
+		if x.Name() == t.Name() {
+			return true
+		}
+		if i, ok := x.(*ggql.Interface); ok && hasInterface(i.Interfaces, t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func lintFiles(t *testing.T, rules []string, sdl ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	paths := make([]string, len(sdl))
+	for i, s := range sdl {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("schema%d.graphql", i+1))
+		if err := ioutil.WriteFile(paths[i], []byte(s), 0600); err != nil {
+			t.Fatalf("write failed. %s", err)
+		}
+	}
+	l, err := newLinter(rules, nil, "Input")
+	if err != nil {
+		t.Fatalf("newLinter failed. %s", err)
+	}
+	if err = l.parse(paths); err != nil {
+		t.Fatalf("parse failed. %s", err)
+	}
+	l.lint()
+	var b strings.Builder
+	for _, issue := range l.issues {
+		fmt.Fprintf(&b, "%s %d:%d %s: %s\n", filepath.Base(issue.File), issue.Line, issue.Column, issue.Rule, issue.Message)
+	}
+	return b.String()
+}
+
+func checkLint(t *testing.T, expect, actual string) {
+	t.Helper()
+	if expect != actual {
+		t.Errorf("lint issues mismatch\nexpect:\n%s\nactual:\n%s", expect, actual)
+	}
+}
+
+func TestLintRules(t *testing.T) {
+	actual := lintFiles(t, nil, `"Query"
+type Query {
+  "Items"
+  items(first_n: Int): [Item]
+  "Old" old: Int @deprecated
+}
+
+"Item"
+type Item {
+  "Kind"
+  kind: Kind
+  Name: String
+}
+
+"Kind"
+enum Kind {
+  big
+  SMALL
+}
+
+"Filter"
+input Filter {
+  "Size"
+  size: Int
+}
+
+"A scalar"
+scalar date_time
+
+type OrderInput {
+  "Id"
+  id: ID
+}
+`)
+	checkLint(t, `schema1.graphql 4:3 nullable-list-item: field items of Query has nullable list items
+schema1.graphql 4:9 field-case: argument first_n of Query.items is not camelCase
+schema1.graphql 5:9 deprecated-reason: field old of Query is deprecated without a reason
+schema1.graphql 12:3 description: field Name of Item has no description
+schema1.graphql 12:3 field-case: field Name of Item is not camelCase
+schema1.graphql 17:3 enum-case: enum value big of Kind is not SCREAMING_CASE
+schema1.graphql 22:7 type-suffix: input type Filter does not end with Input
+schema1.graphql 22:7 unused-type: type Filter is not used
+schema1.graphql 28:8 type-case: type date_time is not PascalCase
+schema1.graphql 28:8 unused-type: type date_time is not used
+schema1.graphql 30:6 description: type OrderInput has no description
+schema1.graphql 30:6 type-suffix: type OrderInput ends with Input but is not an input type
+schema1.graphql 30:6 unused-type: type OrderInput is not used
+`, actual)
+}
+
+func TestLintLocations(t *testing.T) {
+	actual := lintFiles(t, []string{"description"}, `type Query {
+  "Now"
+  now: Date
+}
+`, `
+# Dates without a time.
+scalar Date
+
+enum Mood {
+  "Happy"
+  HAPPY
+}
+`)
+	checkLint(t, `schema1.graphql 1:6 description: type Query has no description
+schema2.graphql 3:8 description: type Date has no description
+schema2.graphql 5:6 description: type Mood has no description
+`, actual)
+}
+
+func TestLintUnknownRule(t *testing.T) {
+	if _, err := newLinter([]string{"no-such-rule"}, nil, "Input"); err == nil {
+		t.Error("an unknown rule should be an error")
+	}
+}
@@ -36,12 +36,13 @@ const (
 
 // Runtime variables.
 var (
-	verbose = false
-	stubDir = ""
-	reflect = false
-	pkg     = "main"
-	embeds  = embedsValue{}
-	overs   = oversValue{}
+	verbose    = false
+	stubDir    = ""
+	reflect    = false
+	mergeStubs = false
+	pkg        = "main"
+	embeds     = embedsValue{}
+	overs      = oversValue{}
 )
 
 func init() {
@@ -50,6 +51,7 @@ func init() {
 
 	flag.StringVar(&stubDir, "s", stubDir, "directory to write stub files to. If not provided no stubs are written")
 	flag.BoolVar(&reflect, "r", reflect, "generate reflection based stubs vs the default of interface based stubs")
+	flag.BoolVar(&mergeStubs, "m", mergeStubs, "merge stubs into existing stub files keeping hand-written code")
 	flag.Var(&embeds, "e", "src-file:embed-file:embed-name")
 	flag.Var(&overs, "w", "overwrite the input file with a re-formatted version")
 }
@@ -129,15 +131,45 @@ that constant as the input SDL.
 If neither a stub directory nor embedded const name is provided only a
 validation is performed.
 
-Stub files are created for input, object, and enum types. The stub files
+Stub files are created for input, object, enum, interface, union, and
+scalar types. Interfaces and unions become Go interfaces that the object
+stubs implement with marker methods. Scalars embed ggql.Scalar and must be
+added to the root with AddTypes() before the schema is parsed. The stub files
 should build in most cases but are not meant to be a complete
-application. Some lint errors are to be expected.
+application. Some lint errors are to be expected. With the -m option
+existing stub files are updated instead of replaced. Stubs are added for
+new types, fields, and enum values, method signatures are updated when
+argument or result types change, and fields, methods, and values that are
+no longer in the schema are marked as deprecated. Method bodies and other
+hand-written code are left as is.
+
+The lint sub-command checks a schema against a set of rules. The client
+sub-command generates a typed Go client for a set of operations. The mock
+sub-command serves a schema with generated values. The fmt sub-command
+formats schema and operation files. Use "ggqlgen <sub-command> -h" for
+details.
 
 Usage: ggqlgen [options] [<schema-file>...]
+       ggqlgen lint [options] [<schema-file>...]
+       ggqlgen client -s <schema-file> [options] <operation-file>...
+       ggqlgen mock [options] <schema-file>...
+       ggqlgen fmt [options] [<graphql-file>...]
 
 `)
 		flag.PrintDefaults()
 	}
+	if 1 < len(os.Args) {
+		switch os.Args[1] {
+		case "lint":
+			os.Exit(lintMain(os.Args[2:]))
+		case "client":
+			os.Exit(clientMain(os.Args[2:]))
+		case "mock":
+			os.Exit(mockMain(os.Args[2:]))
+		case "fmt":
+			os.Exit(formatMain(os.Args[2:]))
+		}
+	}
 	flag.Parse()
 	ggql.Sort = true
 
@@ -315,6 +347,12 @@ func stubGen(root *ggql.Root) (err error) {
 	if err = ioutil.WriteFile(path, []byte(b.String()), 0600); err != nil {
 		return err
 	}
+	// Scalars such as Time and Int64 are included in every root but are not
+	// core types so skip any scalar that is in a new root.
+	builtin := map[string]bool{}
+	for _, t := range ggql.NewRoot(nil).Types() {
+		builtin[t.Name()] = true
+	}
 	for _, t := range root.Types() {
 		if t.Core() {
 			continue
@@ -326,6 +364,16 @@ func stubGen(root *ggql.Root) (err error) {
 			err = stubInput(tt)
 		case *ggql.Enum:
 			err = stubEnum(tt)
+		case *ggql.Interface:
+			err = stubInterface(root, tt)
+		case *ggql.Union:
+			err = stubUnion(tt)
+		case ggql.OutCoercer:
+			// Scalars defined in SDL are not *ggql.Scalar but all scalars
+			// are OutCoercers and enums have already been handled.
+			if !builtin[t.Name()] {
+				err = stubScalar(t)
+			}
 		}
 		if err != nil {
 			break
@@ -0,0 +1,565 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// stubEdit is a replacement of the source between pos and end with text.
+// An insert has pos equal to end.
+type stubEdit struct {
+	pos  int
+	end  int
+	text string
+}
+
+// stubFile is an existing stub file that is merged with the schema. The
+// file is parsed with go/ast to find what needs to change and the changes
+// are then made to the source so that hand-written code and comments are
+// left as is.
+type stubFile struct {
+	fset  *token.FileSet
+	file  *ast.File
+	src   []byte
+	edits []*stubEdit
+}
+
+// writeStub writes the stub content to path unless merging is enabled and
+// the file already exists in which case merge is called to update the
+// existing file.
+func writeStub(path, content string, merge func(sf *stubFile)) error {
+	if mergeStubs {
+		if _, err := os.Stat(path); err == nil {
+			sf, err := readStubFile(path)
+			if err != nil {
+				return err
+			}
+			merge(sf)
+			src, err := sf.apply()
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			return ioutil.WriteFile(path, src, 0600)
+		}
+	}
+	return ioutil.WriteFile(path, []byte(content), 0600)
+}
+
+func readStubFile(path string) (sf *stubFile, err error) {
+	sf = &stubFile{fset: token.NewFileSet()}
+	if sf.src, err = ioutil.ReadFile(path); err == nil {
+		sf.file, err = parser.ParseFile(sf.fset, path, sf.src, parser.ParseComments)
+	}
+	return
+}
+
+func (sf *stubFile) offset(p token.Pos) int {
+	return sf.fset.Position(p).Offset
+}
+
+func (sf *stubFile) text(n ast.Node) string {
+	return string(sf.src[sf.offset(n.Pos()):sf.offset(n.End())])
+}
+
+func (sf *stubFile) insert(p token.Pos, text string) {
+	pos := sf.offset(p)
+	sf.edits = append(sf.edits, &stubEdit{pos: pos, end: pos, text: text})
+}
+
+func (sf *stubFile) replace(n ast.Node, text string) {
+	sf.edits = append(sf.edits, &stubEdit{pos: sf.offset(n.Pos()), end: sf.offset(n.End()), text: text})
+}
+
+func (sf *stubFile) appendText(text string) {
+	sf.edits = append(sf.edits, &stubEdit{pos: len(sf.src), end: len(sf.src), text: "\n" + text})
+}
+
+// apply the edits and format the result.
+func (sf *stubFile) apply() ([]byte, error) {
+	var added strings.Builder
+	for _, e := range sf.edits {
+		added.WriteString(e.text)
+	}
+	for _, imp := range []string{"fmt", "time", "ggql"} {
+		if strings.Contains(added.String(), imp+".") {
+			path := imp
+			if imp == "ggql" {
+				path = "github.com/uhn/ggql/pkg/ggql"
+			}
+			sf.addImport(path)
+		}
+	}
+	// Apply from the end so the offsets of the remaining edits are not
+	// changed. Edits at the same position are applied so they end up in
+	// the order they were added.
+	edits := make([]*stubEdit, len(sf.edits))
+	for i, e := range sf.edits {
+		edits[len(edits)-1-i] = e
+	}
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].pos > edits[j].pos })
+	src := sf.src
+	for _, e := range edits {
+		src = append(src[:e.pos:e.pos], append([]byte(e.text), src[e.end:]...)...)
+	}
+	return format.Source(src)
+}
+
+func (sf *stubFile) addImport(path string) {
+	quoted := strconv.Quote(path)
+	for _, imp := range sf.file.Imports {
+		if imp.Path.Value == quoted {
+			return
+		}
+	}
+	for _, decl := range sf.file.Decls {
+		if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+			if gd.Lparen.IsValid() {
+				sf.insert(gd.Lparen+1, "\n\t"+quoted)
+			} else {
+				sf.insert(gd.Pos(), "import "+quoted+"\n")
+			}
+			return
+		}
+	}
+	sf.insert(sf.file.Name.End(), "\n\nimport "+quoted)
+}
+
+func (sf *stubFile) findStruct(name string) *ast.StructType {
+	for _, decl := range sf.file.Decls {
+		if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+			for _, spec := range gd.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == name {
+					st, _ := ts.Type.(*ast.StructType)
+					return st
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// methods returns the methods on the type named recv.
+func (sf *stubFile) methods(recv string) map[string]*ast.FuncDecl {
+	methods := map[string]*ast.FuncDecl{}
+	for _, decl := range sf.file.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Recv == nil || len(fd.Recv.List) != 1 {
+			continue
+		}
+		rt := fd.Recv.List[0].Type
+		if star, ok := rt.(*ast.StarExpr); ok {
+			rt = star.X
+		}
+		if id, ok := rt.(*ast.Ident); ok && id.Name == recv {
+			methods[fd.Name.Name] = fd
+		}
+	}
+	return methods
+}
+
+// markOrphan adds a deprecation comment before a declaration or field that
+// no longer has a match in the schema.
+func (sf *stubFile) markOrphan(doc *ast.CommentGroup, at token.Pos, indent, what string) {
+	if doc != nil && strings.Contains(doc.Text(), "Deprecated:") {
+		return
+	}
+	text := fmt.Sprintf("// Deprecated: %s is no longer in the schema.\n%s", what, indent)
+	if doc != nil {
+		text = "//\n" + indent + text
+	}
+	sf.insert(at, text)
+}
+
+// commentBefore returns the comment group that ends on the line before p or
+// nil if there is none. Statements such as case clauses do not have a doc
+// comment in the syntax tree.
+func (sf *stubFile) commentBefore(p token.Pos) *ast.CommentGroup {
+	line := sf.fset.Position(p).Line
+	for _, cg := range sf.file.Comments {
+		if sf.fset.Position(cg.End()).Line == line-1 {
+			return cg
+		}
+	}
+	return nil
+}
+
+// stubField is a struct field expected by the schema.
+type stubField struct {
+	name string
+	desc string
+	t    ggql.Type
+}
+
+// mergeStruct adds missing fields, updates field types that have changed,
+// and marks fields that are no longer in the schema. The known names are
+// schema names that have a counterpart other than a struct field.
+func (sf *stubFile) mergeStruct(typeName string, st *ast.StructType, fields []*stubField, known map[string]bool) {
+	have := map[string]*ast.Field{}
+	for _, f := range st.Fields.List {
+		for _, id := range f.Names {
+			have[id.Name] = f
+		}
+	}
+	var b strings.Builder
+	for _, f := range fields {
+		public := publicName(f.name)
+		known[public] = true
+		if af := have[public]; af != nil {
+			if ts := typeStr(f.t); len(af.Names) == 1 && sf.text(af.Type) != ts {
+				sf.replace(af.Type, ts)
+			}
+			continue
+		}
+		stubStructField(&b, f.name, f.desc, f.t)
+	}
+	if 0 < b.Len() {
+		sf.insert(st.Fields.Closing, b.String())
+	}
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 1 && f.Names[0].IsExported() && !known[f.Names[0].Name] {
+			sf.markOrphan(f.Doc, f.Pos(), "\t", fmt.Sprintf("%s.%s", typeName, f.Names[0].Name))
+		}
+	}
+}
+
+func (sf *stubFile) mergeObject(t *ggql.Object) {
+	known := map[string]bool{}
+	var fields []*stubField
+	for _, f := range t.Fields() {
+		if len(f.Args()) == 0 {
+			fields = append(fields, &stubField{name: f.N, desc: f.Desc, t: f.Type})
+		} else {
+			known[publicName(f.N)] = true
+		}
+	}
+	if st := sf.findStruct(t.Name()); st != nil {
+		sf.mergeStruct(t.Name(), st, fields, known)
+	} else {
+		var b strings.Builder
+		_ = stubObjectStruct(&b, t)
+		sf.appendText(b.String())
+	}
+	methods := sf.methods(t.Name())
+	changed := map[string]bool{}
+	for _, f := range t.Fields() {
+		if len(f.Args()) == 0 {
+			continue
+		}
+		fd := methods[publicName(f.N)]
+		if fd == nil {
+			var b strings.Builder
+			stubObjectFunc(&b, t, f)
+			sf.appendText(b.String())
+			continue
+		}
+		changed[f.N] = sf.mergeSignature(fd, f)
+	}
+	for name, fd := range methods {
+		if ast.IsExported(name) && name != "Resolve" && !known[name] && isStubResults(fd) {
+			sf.markOrphan(fd.Doc, fd.Pos(), "", fmt.Sprintf("%s.%s", t.Name(), name))
+		}
+	}
+	if !reflect {
+		if fd := methods["Resolve"]; fd != nil {
+			sf.mergeResolve(fd, t, changed)
+		} else {
+			var b strings.Builder
+			_ = stubObjectResolve(&b, t)
+			sf.appendText(b.String())
+		}
+	}
+}
+
+// isStubResults returns true if the method results are named result and
+// err as with generated methods.
+func isStubResults(fd *ast.FuncDecl) bool {
+	res := fd.Type.Results
+	if res == nil || len(res.List) != 2 || len(res.List[0].Names) != 1 || len(res.List[1].Names) != 1 {
+		return false
+	}
+	return res.List[0].Names[0].Name == "result" && res.List[1].Names[0].Name == "err"
+}
+
+// mergeSignature replaces the parameters and results of a method if the
+// argument or result types of the field have changed. The body is not
+// changed. True is returned if the signature was replaced.
+func (sf *stubFile) mergeSignature(fd *ast.FuncDecl, f *ggql.FieldDef) bool {
+	var types []string
+	for _, p := range fd.Type.Params.List {
+		n := len(p.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			types = append(types, sf.text(p.Type))
+		}
+	}
+	same := len(types) == len(f.Args())
+	for i, a := range f.Args() {
+		if same && types[i] != typeStr(a.Type) {
+			same = false
+		}
+	}
+	res := fd.Type.Results
+	if same && (res == nil || len(res.List) == 0 || sf.text(res.List[0].Type) != typeStr(f.Type)) {
+		same = false
+	}
+	if same {
+		return false
+	}
+	end := fd.Type.Params.End()
+	if res != nil {
+		end = res.End()
+	}
+	sf.edits = append(sf.edits, &stubEdit{
+		pos:  sf.offset(fd.Type.Params.Pos()),
+		end:  sf.offset(end),
+		text: funcSignature(f),
+	})
+	return true
+}
+
+// mergeResolve adds cases to the field.Name switch in the Resolve method for
+// fields that do not have a case, replaces the cases for fields with a
+// changed method signature, and marks the cases for fields that are no
+// longer in the schema.
+func (sf *stubFile) mergeResolve(fd *ast.FuncDecl, t *ggql.Object, changed map[string]bool) {
+	var sw *ast.SwitchStmt
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		if s, ok := n.(*ast.SwitchStmt); ok && sw == nil && s.Tag != nil && sf.text(s.Tag) == "field.Name" {
+			sw = s
+		}
+		return sw == nil
+	})
+	if sw == nil {
+		return
+	}
+	have := map[string]bool{}
+	for _, stmt := range sw.Body.List {
+		cc, ok := stmt.(*ast.CaseClause)
+		if !ok {
+			continue
+		}
+		var orphans []string
+		for _, x := range cc.List {
+			if lit, ok := x.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				if s, err := strconv.Unquote(lit.Value); err == nil {
+					have[s] = true
+					f := t.GetField(s)
+					if f == nil {
+						orphans = append(orphans, fmt.Sprintf("%s.%s", t.Name(), s))
+					} else if changed[s] && len(cc.List) == 1 {
+						var b strings.Builder
+						stubResolveCase(&b, f)
+						sf.replace(cc, strings.TrimSpace(b.String()))
+					}
+				}
+			}
+		}
+		if 0 < len(orphans) && len(orphans) == len(cc.List) {
+			sf.markOrphan(sf.commentBefore(cc.Pos()), cc.Pos(), "\t", strings.Join(orphans, " and "))
+		}
+	}
+	var b strings.Builder
+	for _, f := range t.Fields() {
+		if !have[f.Name()] {
+			stubResolveCase(&b, f)
+		}
+	}
+	if 0 < b.Len() {
+		sf.insert(sw.Body.Rbrace, b.String())
+	}
+}
+
+func (sf *stubFile) mergeInput(t *ggql.Input) {
+	var fields []*stubField
+	for _, f := range t.Fields() {
+		fields = append(fields, &stubField{name: f.N, desc: f.Desc, t: f.Type})
+	}
+	if st := sf.findStruct(t.Name()); st != nil {
+		sf.mergeStruct(t.Name(), st, fields, map[string]bool{})
+	} else {
+		var b strings.Builder
+		b.WriteString(fmt.Sprintf("// %s %s\ntype %s struct {\n", t.Name(), dotdotdot, t.Name()))
+		for _, f := range fields {
+			stubStructField(&b, f.name, f.desc, f.t)
+		}
+		b.WriteString("}\n")
+		sf.appendText(b.String())
+	}
+}
+
+func (sf *stubFile) mergeEnum(t *ggql.Enum) {
+	var block *ast.GenDecl
+	have := map[string]bool{}
+	for _, decl := range sf.file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Names) != 1 || !strings.HasPrefix(vs.Names[0].Name, t.Name()) {
+				continue
+			}
+			if block == nil && gd.Lparen.IsValid() {
+				block = gd
+			}
+			name := vs.Names[0].Name
+			have[name] = true
+			if t.Values() != nil && !enumHas(t, name) && len(vs.Values) == 1 &&
+				sf.text(vs.Values[0]) == strconv.Quote(strings.TrimPrefix(name, t.Name())) {
+				sf.markOrphan(vs.Doc, vs.Pos(), "\t", name)
+			}
+		}
+	}
+	var b strings.Builder
+	for _, ev := range t.Values() {
+		if !have[t.Name()+string(ev.Value)] {
+			stubEnumValue(&b, t, ev)
+		}
+	}
+	if b.Len() == 0 {
+		return
+	}
+	if block != nil {
+		sf.insert(block.Rparen, b.String())
+	} else {
+		sf.appendText("const (\n" + b.String() + ")\n")
+	}
+}
+
+func enumHas(t *ggql.Enum, name string) bool {
+	for _, ev := range t.Values() {
+		if t.Name()+string(ev.Value) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeAbstract adds the interface declaration if missing, embeds missing
+// parent interfaces, adds missing marker methods, and marks the marker
+// methods of objects that no longer implement the interface or are no
+// longer union members.
+func (sf *stubFile) mergeAbstract(name, desc string, parents, impls []string) {
+	var it *ast.InterfaceType
+	for _, decl := range sf.file.Decls {
+		if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+			for _, spec := range gd.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == name {
+					it, _ = ts.Type.(*ast.InterfaceType)
+				}
+			}
+		}
+	}
+	if it == nil {
+		var b strings.Builder
+		stubAbstractDecl(&b, name, desc, parents)
+		sf.appendText(b.String())
+	} else {
+		embedded := map[string]bool{}
+		at := it.Methods.Opening + 1
+		for _, m := range it.Methods.List {
+			if len(m.Names) == 0 {
+				embedded[sf.text(m.Type)] = true
+				at = m.End()
+			}
+		}
+		var b strings.Builder
+		for _, p := range parents {
+			if !embedded[p] {
+				b.WriteString(fmt.Sprintf("\n\t%s", p))
+			}
+		}
+		if 0 < b.Len() {
+			sf.insert(at, b.String())
+		}
+	}
+	want := map[string]bool{}
+	for _, impl := range impls {
+		want[impl] = true
+	}
+	marker := markerName(name)
+	for _, decl := range sf.file.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Name.Name != marker || fd.Recv == nil || len(fd.Recv.List) != 1 {
+			continue
+		}
+		rt := fd.Recv.List[0].Type
+		if star, ok := rt.(*ast.StarExpr); ok {
+			rt = star.X
+		}
+		if id, ok := rt.(*ast.Ident); ok {
+			if want[id.Name] {
+				delete(want, id.Name)
+			} else {
+				sf.markOrphan(fd.Doc, fd.Pos(), "", fmt.Sprintf("%s as a %s", id.Name, name))
+			}
+		}
+	}
+	for _, impl := range impls {
+		if want[impl] {
+			var b strings.Builder
+			stubMarker(&b, impl, name)
+			sf.appendText(b.String())
+		}
+	}
+}
+
+// mergeScalar adds the scalar type, the function that creates the scalar,
+// and the coerce methods if any are missing.
+func (sf *stubFile) mergeScalar(t ggql.Type) {
+	var b strings.Builder
+	if sf.findStruct(t.Name()) == nil {
+		typeComment(&b, t.Name(), t.Description())
+		b.WriteString(fmt.Sprintf("type %s struct {\n\tggql.Scalar\n}\n", t.Name()))
+		sf.appendText(b.String())
+	}
+	hasNew := false
+	for _, decl := range sf.file.Decls {
+		if fd, ok := decl.(*ast.FuncDecl); ok && fd.Recv == nil && fd.Name.Name == "New"+t.Name() {
+			hasNew = true
+		}
+	}
+	if !hasNew {
+		b.Reset()
+		stubScalarNew(&b, t)
+		sf.appendText(b.String())
+	}
+	methods := sf.methods(t.Name())
+	if methods["CoerceIn"] == nil {
+		b.Reset()
+		stubScalarCoerceIn(&b, t)
+		sf.appendText(b.String())
+	}
+	if methods["CoerceOut"] == nil {
+		b.Reset()
+		stubScalarCoerceOut(&b, t)
+		sf.appendText(b.String())
+	}
+}
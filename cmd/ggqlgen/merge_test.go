@@ -0,0 +1,137 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+const mergeSDL = `
+type Query {
+  artist(name: String!): Artist
+  count: Int
+}
+
+"An artist."
+type Artist {
+  name: String!
+  origin: [String]
+  genre: String
+  songs(first: Int, after: String): [String]
+  members(limit: Int): [String]
+}
+`
+
+// genStubs writes the existing files to a new stub directory and then
+// generates the stubs for the schema into that directory.
+func genStubs(t *testing.T, sdl string, merge bool, existing map[string][]byte) string {
+	t.Helper()
+	saveDir, saveReflect, saveMerge, savePkg := stubDir, reflect, mergeStubs, pkg
+	t.Cleanup(func() {
+		stubDir, reflect, mergeStubs, pkg = saveDir, saveReflect, saveMerge, savePkg
+	})
+	stubDir, reflect, mergeStubs, pkg = t.TempDir(), false, merge, "main"
+	for name, src := range existing {
+		if err := ioutil.WriteFile(filepath.Join(stubDir, name), src, 0600); err != nil {
+			t.Fatalf("write failed. %s", err)
+		}
+	}
+	root := ggql.NewRoot(nil)
+	if err := root.ParseString(sdl); err != nil {
+		t.Fatalf("parse failed. %s", err)
+	}
+	if err := stubGen(root); err != nil {
+		t.Fatalf("stubGen failed. %s", err)
+	}
+	return stubDir
+}
+
+func readStub(t *testing.T, dir, name string) []byte {
+	t.Helper()
+	src, err := ioutil.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("read failed. %s", err)
+	}
+	return src
+}
+
+// checkGolden compares actual to the golden file in testdata. Run the tests
+// with -update to write the golden files instead.
+func checkGolden(t *testing.T, golden string, actual []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", golden)
+	if *update {
+		if err := ioutil.WriteFile(path, actual, 0600); err != nil {
+			t.Fatalf("write failed. %s", err)
+		}
+		return
+	}
+	expect, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read failed. %s", err)
+	}
+	if string(expect) != string(actual) {
+		t.Errorf("%s mismatch\nexpect:\n%s\nactual:\n%s", golden, expect, actual)
+	}
+}
+
+// vetStubs runs go vet on the stubs in dir with the ggql package taken
+// from this module.
+func vetStubs(t *testing.T, dir string) {
+	t.Helper()
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	var modDir string
+	if modDir, err = filepath.Abs(filepath.Join("..", "..")); err != nil {
+		t.Fatalf("module directory not found. %s", err)
+	}
+	mod := "module stubs\n\ngo 1.16\n\nrequire github.com/uhn/ggql v0.0.0\n\nreplace github.com/uhn/ggql => " + modDir + "\n"
+	if err = ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte(mod), 0600); err != nil {
+		t.Fatalf("write failed. %s", err)
+	}
+	cmd := exec.Command(goCmd, "vet", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go vet of the stubs failed. %s\n%s", err, out)
+	}
+}
+
+func TestMergeStubs(t *testing.T) {
+	// The existing file has hand-written bodies and comments. The schema
+	// changes the origin type and the songs arguments, adds genre and
+	// members, and removes rating and likes.
+	existing := map[string][]byte{"artist.go": readStub(t, "testdata", filepath.Join("merge", "artist.go"))}
+	dir := genStubs(t, mergeSDL, true, existing)
+	merged := readStub(t, dir, "artist.go")
+	checkGolden(t, filepath.Join("merge", "artist.golden"), merged)
+	vetStubs(t, dir)
+
+	dir = genStubs(t, mergeSDL, true, map[string][]byte{"artist.go": merged})
+	if again := readStub(t, dir, "artist.go"); string(again) != string(merged) {
+		t.Errorf("merging again should not change the file\nexpect:\n%s\nactual:\n%s", merged, again)
+	}
+}
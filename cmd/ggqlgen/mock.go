@@ -0,0 +1,138 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// mockMain runs the mock sub-command and returns the exit code. It only
+// returns if the server fails to start.
+func mockMain(args []string) int {
+	fs := flag.NewFlagSet("mock", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	seed := fs.Int64("seed", 0, "seed for the generated values")
+	listLen := fs.Int("list", 3, "number of elements in generated lists")
+	fixtures := fs.String("fixtures", "", "JSON file with values to use for types instead of generated values")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `
+ggqlgen mock serves the schema on /graphql with generated values for every
+field. The same seed and request always return the same result. Requests
+can be a GET with a query parameter or a POST with either a JSON body that
+includes the query, operationName, and variables or a body that is just
+the query.
+
+The fixtures file is a JSON object with type names as keys. For an object
+type the value is an object with field values and fields that are not
+included are generated. For a scalar or enum type the value is used as is.
+A list value has one of its elements picked for each value.
+
+Usage: ggqlgen mock [options] <schema-file>...
+
+`)
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	opts := ggql.MockOptions{Seed: *seed, ListLen: *listLen}
+	if 0 < len(*fixtures) {
+		js, err := ioutil.ReadFile(*fixtures)
+		if err == nil {
+			err = json.Unmarshal(js, &opts.Fixtures)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read fixtures file %s: %s\n", *fixtures, err)
+			return 2
+		}
+	}
+	root := ggql.NewMockRoot(&opts)
+	var buf []byte
+	for _, path := range fs.Args() {
+		sdl, err := getSDL(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read schema file %s: %s\n", path, err)
+			return 2
+		}
+		buf = append(buf, sdl...)
+		buf = append(buf, '\n')
+	}
+	if err := root.Parse(buf); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to parse schema: %s\n", err)
+		return 2
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, req *http.Request) {
+		handleMock(w, req, root)
+	})
+	fmt.Fprintf(os.Stderr, "Serving mock schema on %s/graphql\n", *addr)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		fmt.Fprintf(os.Stderr, "Server failed: %s\n", err)
+	}
+	return 1
+}
+
+func handleMock(w http.ResponseWriter, req *http.Request, root *ggql.Root) {
+	var query struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	// Allow a frontend served from elsewhere to use the mock server.
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+	switch req.Method {
+	case "GET":
+		q := req.URL.Query()
+		query.Query = q.Get("query")
+		query.OperationName = q.Get("operationName")
+		if vars := q.Get("variables"); 0 < len(vars) {
+			if err := json.Unmarshal([]byte(vars), &query.Variables); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	case "POST":
+		defer func() { _ = req.Body.Close() }()
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+			if err = json.Unmarshal(body, &query); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		} else {
+			query.Query = string(body)
+		}
+	case "OPTIONS":
+		return
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	result := root.ResolveString(query.Query, query.OperationName, query.Variables)
+	w.Header().Set("Content-Type", "application/json")
+	_ = ggql.WriteJSONValue(w, result, -1)
+}
@@ -16,7 +16,6 @@ package main
 
 import (
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
 	"strings"
 
@@ -69,73 +68,82 @@ TOP:
 	}
 	path := filepath.Join(stubDir, strings.ToLower(t.Name())+".go")
 
-	return ioutil.WriteFile(path, []byte(b.String()), 0600)
+	return writeStub(path, b.String(), func(sf *stubFile) { sf.mergeObject(t) })
 }
 
 func stubObjectStruct(b *strings.Builder, t *ggql.Object) (err error) {
-	desc := t.Description()
-	if len(desc) == 0 {
-		desc = dotdotdot
-	}
-	if strings.HasPrefix(desc, t.Name()+" ") {
-		b.WriteString(fmt.Sprintf("// %s\n", strings.ReplaceAll(desc, "\n", "\n// ")))
-	} else {
-		b.WriteString(fmt.Sprintf("// %s %s\n", t.Name(), strings.ReplaceAll(desc, "\n", "\n// ")))
-	}
+	typeComment(b, t.Name(), t.Description())
 	b.WriteString(fmt.Sprintf("type %s struct {\n", t.Name()))
 	for _, f := range t.Fields() {
 		if 0 < len(f.Args()) {
 			continue
 		}
-		public := publicName(f.N)
-		desc = f.Desc
-		if len(desc) == 0 {
-			desc = dotdotdot
-		}
-		if strings.HasPrefix(desc, public+" ") {
-			b.WriteString(fmt.Sprintf("\n\t// %s\n", strings.ReplaceAll(desc, "\n", "\n\t// ")))
-		} else {
-			b.WriteString(fmt.Sprintf("\n\t// %s %s\n", public, strings.ReplaceAll(desc, "\n", "\n\t// ")))
-		}
-		b.WriteString(fmt.Sprintf("\t%s %s\n", public, typeStr(f.Type)))
+		stubStructField(b, f.N, f.Desc, f.Type)
 	}
 	b.WriteString("}\n\n")
 
 	return
 }
 
+// stubStructField writes a struct field with a comment.
+func stubStructField(b *strings.Builder, name, desc string, t ggql.Type) {
+	public := publicName(name)
+	if len(desc) == 0 {
+		desc = dotdotdot
+	}
+	if strings.HasPrefix(desc, public+" ") {
+		b.WriteString(fmt.Sprintf("\n\t// %s\n", strings.ReplaceAll(desc, "\n", "\n\t// ")))
+	} else {
+		b.WriteString(fmt.Sprintf("\n\t// %s %s\n", public, strings.ReplaceAll(desc, "\n", "\n\t// ")))
+	}
+	b.WriteString(fmt.Sprintf("\t%s %s\n", public, typeStr(t)))
+}
+
 func stubObjectFuncs(b *strings.Builder, t *ggql.Object) (err error) {
 	for _, f := range t.Fields() {
 		if len(f.Args()) == 0 {
 			continue
 		}
-		public := publicName(f.N)
-		desc := f.Desc
-		if len(desc) == 0 {
-			desc = dotdotdot
-		}
-		if strings.HasPrefix(desc, public+" ") {
-			b.WriteString(fmt.Sprintf("// %s\n", strings.ReplaceAll(desc, "\n", "\n\t// ")))
-		} else {
-			b.WriteString(fmt.Sprintf("// %s %s\n", public, strings.ReplaceAll(desc, "\n", "\n\t// ")))
-		}
-		b.WriteString(fmt.Sprintf("func (t *%s) %s(", t.Name(), public))
-		for i, a := range f.Args() {
-			if 0 < i {
-				b.WriteString(fmt.Sprintf(", %s %s", a.N, typeStr(a.Type)))
-			} else {
-				b.WriteString(fmt.Sprintf("%s %s", a.N, typeStr(a.Type)))
-			}
-		}
-		b.WriteString(fmt.Sprintf(") (result %s, err error) {\n\n", typeStr(f.Type)))
+		stubObjectFunc(b, t, f)
+	}
+	return
+}
+
+// stubObjectFunc writes a method for a field with arguments.
+func stubObjectFunc(b *strings.Builder, t *ggql.Object, f *ggql.FieldDef) {
+	public := publicName(f.N)
+	desc := f.Desc
+	if len(desc) == 0 {
+		desc = dotdotdot
+	}
+	if strings.HasPrefix(desc, public+" ") {
+		b.WriteString(fmt.Sprintf("// %s\n", strings.ReplaceAll(desc, "\n", "\n\t// ")))
+	} else {
+		b.WriteString(fmt.Sprintf("// %s %s\n", public, strings.ReplaceAll(desc, "\n", "\n\t// ")))
+	}
+	b.WriteString(fmt.Sprintf("func (t *%s) %s%s {\n\n", t.Name(), public, funcSignature(f)))
 
-		b.WriteString("\t// FIXME\n")
-		b.WriteString("\terr = fmt.Errorf(\"not implemented yet\")\n\n")
+	b.WriteString("\t// FIXME\n")
+	b.WriteString("\terr = fmt.Errorf(\"not implemented yet\")\n\n")
+
+	b.WriteString("\treturn\n")
+	b.WriteString("}\n\n")
+}
 
-		b.WriteString("\treturn\n")
-		b.WriteString("}\n\n")
+// funcSignature returns the parameters and results of the method for a
+// field with arguments.
+func funcSignature(f *ggql.FieldDef) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i, a := range f.Args() {
+		if 0 < i {
+			b.WriteString(", ")
+		}
+		b.WriteString(fmt.Sprintf("%s %s", a.N, typeStr(a.Type)))
 	}
-	return
+	b.WriteString(fmt.Sprintf(") (result %s, err error)", typeStr(f.Type)))
+
+	return b.String()
 }
 
 func stubObjectResolve(b *strings.Builder, t *ggql.Object) (err error) {
@@ -143,24 +151,7 @@ func stubObjectResolve(b *strings.Builder, t *ggql.Object) (err error) {
 	b.WriteString(fmt.Sprintf("func (t *%s) Resolve(field *ggql.Field, args map[string]interface{}) (interface{}, error) {\n", t.Name()))
 	b.WriteString("\tswitch field.Name {\n")
 	for _, f := range t.Fields() {
-		public := publicName(f.Name())
-		b.WriteString(fmt.Sprintf("\tcase \"%s\":\n", f.Name()))
-		if 0 < len(f.Args()) {
-			for _, a := range f.Args() {
-				b.WriteString(fmt.Sprintf("\t\t%s, _ := args[\"%s\"].(%s)\n", a.Name(), a.Name(), typeStr(a.Type)))
-			}
-			b.WriteString(fmt.Sprintf("\n\t\treturn t.%s(", public))
-			for i, a := range f.Args() {
-				if 0 < i {
-					b.WriteString(fmt.Sprintf(", %s", a.Name()))
-				} else {
-					b.WriteString(a.Name())
-				}
-			}
-			b.WriteString(")\n")
-		} else {
-			b.WriteString(fmt.Sprintf("\t\treturn t.%s, nil\n", public))
-		}
+		stubResolveCase(b, f)
 	}
 	b.WriteString("\t}\n")
 
@@ -169,3 +160,25 @@ func stubObjectResolve(b *strings.Builder, t *ggql.Object) (err error) {
 
 	return
 }
+
+// stubResolveCase writes the Resolve switch case for a field.
+func stubResolveCase(b *strings.Builder, f *ggql.FieldDef) {
+	public := publicName(f.Name())
+	b.WriteString(fmt.Sprintf("\tcase \"%s\":\n", f.Name()))
+	if 0 < len(f.Args()) {
+		for _, a := range f.Args() {
+			b.WriteString(fmt.Sprintf("\t\t%s, _ := args[\"%s\"].(%s)\n", a.Name(), a.Name(), typeStr(a.Type)))
+		}
+		b.WriteString(fmt.Sprintf("\n\t\treturn t.%s(", public))
+		for i, a := range f.Args() {
+			if 0 < i {
+				b.WriteString(fmt.Sprintf(", %s", a.Name()))
+			} else {
+				b.WriteString(a.Name())
+			}
+		}
+		b.WriteString(")\n")
+	} else {
+		b.WriteString(fmt.Sprintf("\t\treturn t.%s, nil\n", public))
+	}
+}
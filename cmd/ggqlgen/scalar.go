@@ -0,0 +1,73 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// stubScalar writes a scalar type that embeds ggql.Scalar and implements
+// the ggql.InCoercer and ggql.OutCoercer interfaces along with a function
+// that creates the scalar so it can be added to a root with AddTypes().
+func stubScalar(t ggql.Type) (err error) {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("package %s\n\n", pkg))
+	b.WriteString("import \"github.com/uhn/ggql/pkg/ggql\"\n\n")
+	typeComment(&b, t.Name(), t.Description())
+	b.WriteString(fmt.Sprintf("type %s struct {\n\tggql.Scalar\n}\n\n", t.Name()))
+	stubScalarNew(&b, t)
+	b.WriteString("\n")
+	stubScalarCoerceIn(&b, t)
+	b.WriteString("\n")
+	stubScalarCoerceOut(&b, t)
+
+	path := filepath.Join(stubDir, strings.ToLower(t.Name())+".go")
+
+	return writeStub(path, b.String(), func(sf *stubFile) { sf.mergeScalar(t) })
+}
+
+func stubScalarNew(b *strings.Builder, t ggql.Type) {
+	b.WriteString(fmt.Sprintf("// New%s returns a new %s scalar. Add it to the root with AddTypes()\n", t.Name(), t.Name()))
+	b.WriteString("// before parsing the schema.\n")
+	b.WriteString(fmt.Sprintf("func New%s() ggql.Type {\n", t.Name()))
+	if desc := t.Description(); 0 < len(desc) {
+		b.WriteString(fmt.Sprintf("\treturn &%s{Scalar: ggql.Scalar{Base: ggql.Base{N: %q, Desc: %q}}}\n", t.Name(), t.Name(), desc))
+	} else {
+		b.WriteString(fmt.Sprintf("\treturn &%s{Scalar: ggql.Scalar{Base: ggql.Base{N: %q}}}\n", t.Name(), t.Name()))
+	}
+	b.WriteString("}\n")
+}
+
+func stubScalarCoerceIn(b *strings.Builder, t ggql.Type) {
+	b.WriteString("// CoerceIn coerces an input value into the expected input type if possible\n")
+	b.WriteString("// otherwise an error is returned.\n")
+	b.WriteString(fmt.Sprintf("func (t *%s) CoerceIn(v interface{}) (interface{}, error) {\n\n", t.Name()))
+	b.WriteString("\t// FIXME\n")
+	b.WriteString("\treturn v, nil\n")
+	b.WriteString("}\n")
+}
+
+func stubScalarCoerceOut(b *strings.Builder, t ggql.Type) {
+	b.WriteString("// CoerceOut coerces a result value into a type for the scalar.\n")
+	b.WriteString(fmt.Sprintf("func (t *%s) CoerceOut(v interface{}) (interface{}, error) {\n\n", t.Name()))
+	b.WriteString("\t// FIXME\n")
+	b.WriteString("\treturn v, nil\n")
+	b.WriteString("}\n")
+}
@@ -0,0 +1,51 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+const abstractSDL = `
+type Query {
+  named: [Named]
+  any: [Any]
+  price: Money
+}
+
+"Something with a name."
+interface Named { name: String! }
+
+interface Entity implements Named { name: String! id: ID! }
+
+type Artist implements Named & Entity { name: String! id: ID! }
+
+type Song implements Named { name: String! }
+
+"Anything at all."
+union Any = Artist | Song
+
+"Money in cents."
+scalar Money
+`
+
+func TestStubAbstractAndScalar(t *testing.T) {
+	dir := genStubs(t, abstractSDL, false, nil)
+	for _, name := range []string{"named", "entity", "any", "money"} {
+		checkGolden(t, filepath.Join("stubs", name+".golden"), readStub(t, dir, name+".go"))
+	}
+	vetStubs(t, dir)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// Artist An artist.
+type Artist struct {
+
+	// Name ...
+	Name string
+
+	// Origin is where the artist is from.
+	Origin string
+
+	// Rating ...
+	Rating int
+
+	songs []string
+}
+
+// Songs returns the first songs of the artist.
+func (t *Artist) Songs(first int) (result []string, err error) {
+	if first < len(t.songs) {
+		return t.songs[:first], nil
+	}
+	return t.songs, nil
+}
+
+// Likes ...
+func (t *Artist) Likes(by string) (result int, err error) {
+	// Hand-written body kept even when no longer in the schema.
+	return len(by), nil
+}
+
+// Resolve a field into a value.
+func (t *Artist) Resolve(field *ggql.Field, args map[string]interface{}) (interface{}, error) {
+	switch field.Name {
+	case "name":
+		return t.Name, nil
+	case "origin":
+		return t.Origin, nil
+	// Rating is always shown.
+	case "rating":
+		return t.Rating, nil
+	case "songs":
+		first, _ := args["first"].(int)
+
+		return t.Songs(first)
+	case "likes":
+		by, _ := args["by"].(string)
+
+		return t.Likes(by)
+	}
+	return nil, fmt.Errorf("type Artist does not have field %s", field)
+}
@@ -0,0 +1,30 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// stubUnion writes a Go interface for a GraphQL union along with a marker
+// method for each member. Members are matched to the Go types by name so no
+// @go directive is needed on the members.
+func stubUnion(t *ggql.Union) error {
+	var members []string
+	for _, m := range t.Members {
+		members = append(members, m.Name())
+	}
+	return stubAbstract(t.Name(), t.Description(), nil, members)
+}
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/uhn/ggql/pkg/ggql"
@@ -39,6 +40,8 @@ func typeStr(t ggql.Type) string {
 		return "string"
 	case *ggql.Input, *ggql.Object:
 		return "*" + t.Name()
+	case *ggql.Interface, *ggql.Union:
+		return t.Name()
 	case *ggql.List:
 		return "[]" + typeStr(tt.Base)
 	case *ggql.NonNull:
@@ -47,6 +50,18 @@ func typeStr(t ggql.Type) string {
 	return "interface{}"
 }
 
+// typeComment writes the doc comment for a type declaration.
+func typeComment(b *strings.Builder, name, desc string) {
+	if len(desc) == 0 {
+		desc = dotdotdot
+	}
+	if strings.HasPrefix(desc, name+" ") {
+		b.WriteString(fmt.Sprintf("// %s\n", strings.ReplaceAll(desc, "\n", "\n// ")))
+	} else {
+		b.WriteString(fmt.Sprintf("// %s %s\n", name, strings.ReplaceAll(desc, "\n", "\n// ")))
+	}
+}
+
 func publicName(s string) string {
 	public := strings.Title(s)
 	public = strings.ReplaceAll(public, "Id", "ID")
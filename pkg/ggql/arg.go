@@ -84,6 +84,8 @@ func writeArgs(w io.Writer, args *argList, desc bool) (err error) {
 //   description: String
 //   type: __Type!
 //   defaultValue: String
+//   isDeprecated: Boolean!
+//   deprecationReason: String
 func (a *Arg) Resolve(field *Field, args map[string]interface{}) (result interface{}, err error) {
 	switch field.Name {
 	case nameStr:
@@ -94,6 +96,10 @@ func (a *Arg) Resolve(field *Field, args map[string]interface{}) (result interfa
 		result = a.Type
 	case defaultValueStr:
 		result = a.Default
+	case isDeprecatedStr:
+		result = a.isDeprecated()
+	case deprecationReasonStr:
+		result = a.deprecationReason()
 	}
 	return
 }
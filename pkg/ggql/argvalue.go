@@ -18,7 +18,10 @@ import (
 	"io"
 )
 
-// Sort output values in Object type (maps).
+// Sort output values in Object type (maps). Only the writing of map values,
+// such as with WriteJSONValue(), is affected. It is not the default for the
+// Sort option of a Root. Results formed with the Ordered or Sort option of a
+// Root are OrderedMap values which are not sorted when written.
 var Sort = false
 
 // ArgValue is a GraphQL Arg value.
@@ -113,6 +113,10 @@ func (b *Base) validateFieldDefs(typeName string, fields *fieldList) (errs []err
 					errs = append(errs, fmt.Errorf("%w, argument %s of %s must be an input type at %d:%d",
 						ErrValidation, a.Name(), f.Name(), a.line, a.col))
 				}
+				if _, ok := a.Type.(*NonNull); ok && a.Default == nil && a.isDeprecated() {
+					errs = append(errs, fmt.Errorf("%w, required argument %s of %s can not be deprecated at %d:%d",
+						ErrValidation, a.Name(), f.Name(), a.line, a.col))
+				}
 			}
 			if !IsOutputType(f.Type) {
 				errs = append(errs, fmt.Errorf("%w, %s does not return an output type at %d:%d",
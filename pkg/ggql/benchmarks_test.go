@@ -1,6 +1,7 @@
 package ggql_test
 
 import (
+	"io/ioutil"
 	"log"
 	"testing"
 
@@ -56,3 +57,100 @@ func BenchmarkResolveExecutableAnyResolver(b *testing.B) {
 	}
 	benchmarkResolveExecutable(root, b)
 }
+
+func benchmarkResolveString(root *ggql.Root, b *testing.B) {
+	ggql.Sort = false
+	src := `{__type(name: "Artist"){name} artists{songs{name}}}`
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if result := root.ResolveString(src, "", nil); result["errors"] != nil {
+			b.Errorf("Resolve failed: %v\n", result["errors"])
+		}
+	}
+}
+
+func BenchmarkResolveStringUncached(b *testing.B) {
+	root, err := setupAnySongs()
+	if err != nil {
+		log.Fatalf("setupAnySongs failed: %s", err)
+	}
+	benchmarkResolveString(root, b)
+}
+
+func BenchmarkResolveStringCached(b *testing.B) {
+	root, err := setupAnySongs()
+	if err != nil {
+		log.Fatalf("setupAnySongs failed: %s", err)
+	}
+	root.ExecutableCacheSize = 100
+	benchmarkResolveString(root, b)
+}
+
+func benchmarkResolvePlan(root *ggql.Root, b *testing.B) {
+	ggql.Sort = false
+	exe, err := root.ParseExecutableString(`{__type(name: "Artist"){name} artists{songs{name}}}`)
+	if err != nil {
+		b.Fatalf("Parse executable failed: %s\n", err)
+	}
+	plan, err := root.Compile(exe, "")
+	if err != nil {
+		b.Fatalf("Compile failed: %s\n", err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := root.ResolvePlan(plan, nil); err != nil {
+			b.Errorf("Resolve failed: %s\n", err)
+		}
+	}
+}
+
+func BenchmarkResolvePlanReflection(b *testing.B) {
+	schema := setupReflectSongs()
+	root := ggql.NewRoot(schema)
+	if err := root.AddTypes(NewDateScalar()); err != nil {
+		log.Fatalf("no error should be returned when adding a Date type. %s", err)
+	}
+	if err := root.ParseString(songsSdl); err != nil {
+		log.Fatalf("no error should be returned when parsing a valid SDL. %s", err)
+	}
+	benchmarkResolvePlan(root, b)
+}
+
+func BenchmarkResolvePlanInterface(b *testing.B) {
+	schema := setupSongs()
+	root := ggql.NewRoot(schema)
+	if err := root.AddTypes(NewDateScalar()); err != nil {
+		log.Fatalf("no error should be returned when adding a Date type. %s", err)
+	}
+	if err := root.ParseString(songsSdl); err != nil {
+		log.Fatalf("no error should be returned when parsing a valid SDL. %s", err)
+	}
+	benchmarkResolvePlan(root, b)
+}
+
+func BenchmarkStreamPlanInterface(b *testing.B) {
+	schema := setupSongs()
+	root := ggql.NewRoot(schema)
+	if err := root.AddTypes(NewDateScalar()); err != nil {
+		log.Fatalf("no error should be returned when adding a Date type. %s", err)
+	}
+	if err := root.ParseString(songsSdl); err != nil {
+		log.Fatalf("no error should be returned when parsing a valid SDL. %s", err)
+	}
+	ggql.Sort = false
+	exe, err := root.ParseExecutableString(`{__type(name: "Artist"){name} artists{songs{name}}}`)
+	if err != nil {
+		b.Fatalf("Parse executable failed: %s\n", err)
+	}
+	plan, err := root.Compile(exe, "")
+	if err != nil {
+		b.Fatalf("Compile failed: %s\n", err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := root.StreamPlan(ioutil.Discard, plan, nil); err != nil {
+			b.Errorf("Stream failed: %s\n", err)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CacheScope is the scope of a cache policy. It is the value of the scope
+// argument of a @cacheControl directive.
+type CacheScope string
+
+const (
+	// CachePublic indicates a response can be shared by all clients.
+	CachePublic = CacheScope("PUBLIC")
+
+	// CachePrivate indicates a response is only for the client that made
+	// the request.
+	CachePrivate = CacheScope("PRIVATE")
+)
+
+// CachePolicy is the cache policy of a response as determined by the
+// @cacheControl directives on the types and fields resolved. The MaxAge is
+// the lowest maxAge of any field resolved and the Scope is CachePrivate if
+// any field resolved is private. Fields that return an object, interface,
+// or union and fields of the operation type that have no maxAge use the
+// DefaultMaxAge of the Root. Other fields do not limit the MaxAge.
+type CachePolicy struct {
+
+	// MaxAge is the number of seconds the response can be cached for. Zero
+	// indicates the response should not be cached.
+	MaxAge int
+
+	// Scope of the policy, either CachePublic or CachePrivate.
+	Scope CacheScope
+}
+
+// Header returns the value for an HTTP Cache-Control header for the policy.
+func (cp CachePolicy) Header() string {
+	if cp.MaxAge <= 0 {
+		return "no-store"
+	}
+	if cp.Scope == CachePrivate {
+		return fmt.Sprintf("max-age=%d, private", cp.MaxAge)
+	}
+	return fmt.Sprintf("max-age=%d, public", cp.MaxAge)
+}
+
+// ResponseCache is the interface for caching whole responses. Keys are
+// formed from the normalized operation, the options that change the
+// response, the variables, and the scope along with the Request Session for
+// private responses.
+type ResponseCache interface {
+
+	// Get the response and policy for a key. The MaxAge of the policy
+	// returned should be the number of seconds remaining before the
+	// response expires. If the key is not in the cache or the response has
+	// expired a nil response is returned. The response returned is
+	// returned to the caller who may modify it so it must not be shared.
+	Get(key string) (map[string]interface{}, CachePolicy)
+
+	// Put a response in the cache. The response should expire after the
+	// MaxAge of the policy. The response is also returned to the caller who
+	// may modify it so it must not be kept as is.
+	Put(key string, response map[string]interface{}, policy CachePolicy)
+}
+
+// LRUResponseCache is a ResponseCache that keeps copies of responses in
+// memory. Once the size of the cache is reached the least recently used
+// responses are evicted.
+type LRUResponseCache struct {
+
+	// Now if not nil is used instead of time.Now() to determine when
+	// responses expire.
+	Now func() time.Time
+
+	size    int
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruResponse struct {
+	key      string
+	response map[string]interface{}
+	policy   CachePolicy
+	expires  time.Time
+}
+
+// NewLRUResponseCache returns a new LRUResponseCache that holds no more
+// than size responses.
+func NewLRUResponseCache(size int) *LRUResponseCache {
+	return &LRUResponseCache{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// Get the response and policy for a key.
+func (c *LRUResponseCache) Get(key string) (map[string]interface{}, CachePolicy) {
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.entries[key]
+	if e == nil {
+		return nil, CachePolicy{}
+	}
+	r := e.Value.(*lruResponse)
+	remaining := r.expires.Sub(now)
+	if remaining <= 0 {
+		c.order.Remove(e)
+		delete(c.entries, key)
+		return nil, CachePolicy{}
+	}
+	c.order.MoveToFront(e)
+	policy := r.policy
+	policy.MaxAge = int((remaining + time.Second - 1) / time.Second)
+
+	return copyValue(r.response).(map[string]interface{}), policy
+}
+
+// Put a response in the cache.
+func (c *LRUResponseCache) Put(key string, response map[string]interface{}, policy CachePolicy) {
+	if policy.MaxAge <= 0 {
+		return
+	}
+	r := &lruResponse{
+		key:      key,
+		response: copyValue(response).(map[string]interface{}),
+		policy:   policy,
+		expires:  c.now().Add(time.Duration(policy.MaxAge) * time.Second),
+	}
+	c.mu.Lock()
+	if e := c.entries[key]; e != nil {
+		e.Value = r
+		c.order.MoveToFront(e)
+	} else {
+		c.entries[key] = c.order.PushFront(r)
+	}
+	for c.size < c.order.Len() {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*lruResponse).key)
+	}
+	c.mu.Unlock()
+}
+
+// Len returns the number of responses in the cache including any that have
+// expired but not yet been removed.
+func (c *LRUResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *LRUResponseCache) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+// copyValue returns a deep copy of the maps, lists, and OrderedMaps of a
+// response. Other values are not modified when a response is written so
+// they are not copied.
+func copyValue(v interface{}) interface{} {
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(tv))
+		for k, mv := range tv {
+			m[k] = copyValue(mv)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(tv))
+		for i, lv := range tv {
+			list[i] = copyValue(lv)
+		}
+		return list
+	case *OrderedMap:
+		om := OrderedMap{Keys: append([]string{}, tv.Keys...), Values: make([]interface{}, len(tv.Values))}
+		for i, ov := range tv.Values {
+			om.Values[i] = copyValue(ov)
+		}
+		return &om
+	}
+	return v
+}
+
+// cacheHint is the compiled @cacheControl hint for a field. If limit is
+// false the field does not limit the maxAge of the response. If dflt is
+// true the limit is the DefaultMaxAge of the Root.
+type cacheHint struct {
+	limit   bool
+	dflt    bool
+	maxAge  int
+	private bool
+}
+
+// fieldCacheHint returns the hint for a field from the @cacheControl
+// directives on the field definition and the field type. The field
+// directive takes precedence over the type directive.
+func fieldCacheHint(fd *FieldDef, rootField bool) (h cacheHint) {
+	bt := BaseType(fd.Type)
+	composite := false
+	switch bt.(type) {
+	case *Object, *Interface, *Union:
+		composite = true
+		h.setDirective(bt.Directives())
+	}
+	h.setDirective(fd.Dirs)
+	if !h.limit && (composite || rootField) {
+		h.limit = true
+		h.dflt = true
+	}
+	return
+}
+
+func (h *cacheHint) setDirective(dus []*DirectiveUse) {
+	for _, du := range dus {
+		if du.Directive.Name() != cacheControlStr {
+			continue
+		}
+		if av := du.Args[maxAgeStr]; av != nil {
+			switch tv := av.Value.(type) {
+			case int:
+				h.limit, h.maxAge = true, tv
+			case int32:
+				h.limit, h.maxAge = true, int(tv)
+			case int64:
+				h.limit, h.maxAge = true, int(tv)
+			}
+		}
+		if av := du.Args[scopeStr]; av != nil {
+			if scope, ok := av.Value.(Symbol); ok {
+				h.private = scope == Symbol(CachePrivate)
+			}
+		}
+	}
+}
+
+// restrict applies the hint for a resolved field to the cache policy of the
+// execution.
+func (ex *execution) restrict(h *cacheHint) {
+	if h.limit {
+		age := h.maxAge
+		if h.dflt {
+			age = ex.defaultMaxAge
+		}
+		if ex.maxAge < 0 || age < ex.maxAge {
+			ex.maxAge = age
+		}
+	}
+	if h.private {
+		ex.private = true
+	}
+}
+
+// cachePolicy returns the cache policy for the fields resolved. Only query
+// responses are cacheable.
+func (ex *execution) cachePolicy(op *Op) (policy CachePolicy) {
+	policy.Scope = CachePublic
+	if ex.private {
+		policy.Scope = CachePrivate
+	}
+	if op.Type == OpQuery && 0 < ex.maxAge {
+		policy.MaxAge = ex.maxAge
+	}
+	return
+}
+
+// normalized returns the hash of the operation and fragments of the plan
+// written in a normalized form.
+func (plan *Plan) normalized() string {
+	plan.once.Do(func() {
+		var b bytes.Buffer
+		plan.op.write(&b)
+		if plan.exe != nil {
+			names := make([]string, 0, len(plan.exe.Fragments))
+			for name := range plan.exe.Fragments {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				plan.exe.Fragments[name].write(&b)
+			}
+		}
+		plan.key = QueryHash(b.String())
+	})
+	return plan.key
+}
+
+// cachedResponse returns the response from the ResponseCache for a query if
+// there is one. A private response for the session is preferred over a
+// public response. The key for the query, options, and variables is
+// returned for caching the response once resolved. An empty key indicates
+// the response can not be cached.
+func (root *Root) cachedResponse(
+	plan *Plan,
+	vars map[string]interface{},
+	opts *Options,
+	session string) (response map[string]interface{}, policy CachePolicy, key string) {
+
+	if root.ResponseCache == nil || plan.op.Type != OpQuery {
+		return
+	}
+	key = plan.normalized() + opts.cacheKey()
+	if 0 < len(vars) {
+		js, err := json.Marshal(vars)
+		if err != nil {
+			return nil, policy, ""
+		}
+		key += string(js)
+	}
+	key = QueryHash(key)
+	if 0 < len(session) {
+		if response, policy = root.ResponseCache.Get(privateKey(key, session)); response != nil {
+			return
+		}
+	}
+	response, policy = root.ResponseCache.Get(string(CachePublic) + " " + key)
+
+	return
+}
+
+// cacheResponse puts a response in the ResponseCache if the policy allows.
+// Private responses are only cached if there is a session.
+func (root *Root) cacheResponse(key, session string, response map[string]interface{}, policy CachePolicy) {
+	if len(key) == 0 || policy.MaxAge <= 0 {
+		return
+	}
+	switch {
+	case policy.Scope != CachePrivate:
+		root.ResponseCache.Put(string(CachePublic)+" "+key, response, policy)
+	case 0 < len(session):
+		root.ResponseCache.Put(privateKey(key, session), response, policy)
+	}
+}
+
+func privateKey(key, session string) string {
+	return string(CachePrivate) + " " + key + " " + session
+}
@@ -0,0 +1,278 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+const cacheSdl = `
+type Query {
+  book: Book
+  missing: Book
+  books: [Book] @cacheControl(maxAge: 30)
+  me: User @cacheControl(scope: PRIVATE)
+  title: String @cacheControl(maxAge: 300)
+  count: Int
+}
+
+type Mutation {
+  count: Int
+}
+
+type Book @cacheControl(maxAge: 120) {
+  title: String
+  price: Int @cacheControl(maxAge: 10)
+  author: User
+}
+
+type User @cacheControl(maxAge: 60) {
+  name: String
+}
+`
+
+type cacheSchema struct {
+	Query    *cacheQuery
+	Mutation *cacheQuery
+}
+
+type cacheQuery struct {
+	Book    *cacheBook
+	Missing *cacheBook
+	Books   []*cacheBook
+	Me      *cacheUser
+	Title   string
+	Count   int
+}
+
+type cacheBook struct {
+	Title  string
+	Price  int
+	Author *cacheUser
+}
+
+type cacheUser struct {
+	Name string
+}
+
+func setupCacheRoot(t *testing.T) (*ggql.Root, *cacheQuery) {
+	ggql.Sort = true
+	user := &cacheUser{Name: "Ann"}
+	book := &cacheBook{Title: "Leaves", Price: 12, Author: user}
+	query := &cacheQuery{Book: book, Books: []*cacheBook{book}, Me: user, Title: "Books", Count: 1}
+	root := ggql.NewRoot(&cacheSchema{Query: query, Mutation: query})
+	err := root.AddCacheControl()
+	checkNil(t, err, "AddCacheControl should not fail. %s", err)
+	err = root.ParseString(cacheSdl)
+	checkNil(t, err, "no error should be returned when parsing a valid SDL. %s", err)
+
+	return root, query
+}
+
+func TestCachePolicy(t *testing.T) {
+	root, _ := setupCacheRoot(t)
+	for _, v := range []struct {
+		src    string
+		header string
+	}{
+		{src: `{title}`, header: "max-age=300, public"},
+		{src: `{count}`, header: "no-store"},
+		{src: `{book{title}}`, header: "max-age=120, public"},
+		{src: `{book{title price}}`, header: "max-age=10, public"},
+		{src: `{books{title}}`, header: "max-age=30, public"},
+		{src: `{title me{name}}`, header: "max-age=60, private"},
+		{src: `{book{author{name}}}`, header: "max-age=60, public"},
+		{src: `{title missing{price}}`, header: "max-age=120, public"},
+		{src: `mutation {count}`, header: "no-store"},
+	} {
+		result, policy := root.ResolveRequestPolicy(&ggql.Request{Query: v.src})
+		checkNil(t, result["errors"], "unexpected errors for %s", v.src)
+		checkEqual(t, v.header, policy.Header(), "cache header for %s", v.src)
+	}
+	result, policy := root.ResolveRequestPolicy(&ggql.Request{Query: `{title book{bad}}`})
+	checkNotNil(t, result["errors"], "expected an error")
+	checkEqual(t, "no-store", policy.Header(), "responses with errors are not cacheable")
+
+	root.DefaultMaxAge = 600
+	_, policy = root.ResolveRequestPolicy(&ggql.Request{Query: `{count}`})
+	checkEqual(t, 600, policy.MaxAge, "default max age")
+	checkEqual(t, "PUBLIC", string(policy.Scope), "default scope")
+}
+
+func TestCacheControlDeclared(t *testing.T) {
+	ggql.Sort = true
+	user := &cacheUser{Name: "Ann"}
+	book := &cacheBook{Title: "Leaves", Price: 12, Author: user}
+	query := &cacheQuery{Book: book, Books: []*cacheBook{book}, Me: user, Title: "Books", Count: 1}
+	root := ggql.NewRoot(&cacheSchema{Query: query, Mutation: query})
+	err := root.ParseString(`
+enum CacheControlScope { PUBLIC PRIVATE }
+directive @cacheControl(maxAge: Int, scope: CacheControlScope) on FIELD_DEFINITION | OBJECT
+` + cacheSdl)
+	checkNil(t, err, "a schema declaring @cacheControl should parse. %s", err)
+
+	_, policy := root.ResolveRequestPolicy(&ggql.Request{Query: `{book{title price}}`})
+	checkEqual(t, "max-age=10, public", policy.Header(), "declared directive cache header")
+	_, policy = root.ResolveRequestPolicy(&ggql.Request{Query: `{title me{name}}`})
+	checkEqual(t, "max-age=60, private", policy.Header(), "declared directive private cache header")
+
+	err = root.AddCacheControl()
+	checkNotNil(t, err, "AddCacheControl should fail when the schema declares @cacheControl")
+}
+
+func TestResponseCache(t *testing.T) {
+	root, query := setupCacheRoot(t)
+	cache := ggql.NewLRUResponseCache(10)
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	cache.Now = func() time.Time { return now }
+	root.ResponseCache = cache
+
+	src := `query($x: Boolean!) { title book @include(if: $x) { title } }`
+	vars := map[string]interface{}{"x": true}
+	_, policy := root.ResolveRequestPolicy(&ggql.Request{Query: src, Variables: vars})
+	checkEqual(t, "max-age=120, public", policy.Header(), "first request")
+	checkEqual(t, 1, cache.Len(), "cached responses")
+
+	query.Title = "Changed"
+	now = now.Add(20 * time.Second)
+	// Formatting differences are normalized.
+	src2 := "query ($x: Boolean!) {\n  title\n  book @include(if: $x) {title}\n}"
+	cached, policy := root.ResolveRequestPolicy(&ggql.Request{Query: src2, Variables: vars})
+	checkEqual(t, 100, policy.MaxAge, "remaining max age")
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, cached, -1)
+	checkEqual(t, `{"data":{"book":{"title":"Leaves"},"title":"Books"}}`, b.String(), "cached response")
+
+	// Different variables are a different response.
+	result, _ := root.ResolveRequestPolicy(&ggql.Request{Query: src, Variables: map[string]interface{}{"x": false}})
+	b.Reset()
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, `{"data":{"title":"Changed"}}`, b.String(), "response for other variables")
+
+	// Expired responses are resolved again.
+	now = now.Add(101 * time.Second)
+	result, _ = root.ResolveRequestPolicy(&ggql.Request{Query: src, Variables: vars})
+	b.Reset()
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, `{"data":{"book":{"title":"Leaves"},"title":"Changed"}}`, b.String(), "expired response")
+}
+
+func TestResponseCachePrivate(t *testing.T) {
+	root, query := setupCacheRoot(t)
+	cache := ggql.NewLRUResponseCache(10)
+	root.ResponseCache = cache
+
+	src := `{me{name}}`
+	_, policy := root.ResolveRequestPolicy(&ggql.Request{Query: src})
+	checkEqual(t, "PRIVATE", string(policy.Scope), "private scope")
+	checkEqual(t, 0, cache.Len(), "private responses without a session are not cached")
+
+	_, _ = root.ResolveRequestPolicy(&ggql.Request{Query: src, Session: "ann"})
+	checkEqual(t, 1, cache.Len(), "private response with a session")
+
+	query.Me = &cacheUser{Name: "Bob"}
+	var b strings.Builder
+	result, _ := root.ResolveRequestPolicy(&ggql.Request{Query: src, Session: "bob"})
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, `{"data":{"me":{"name":"Bob"}}}`, b.String(), "other session")
+
+	b.Reset()
+	result, _ = root.ResolveRequestPolicy(&ggql.Request{Query: src, Session: "ann"})
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, `{"data":{"me":{"name":"Ann"}}}`, b.String(), "same session")
+
+	// Public responses are shared across sessions and errors are not cached.
+	_, _ = root.ResolveRequestPolicy(&ggql.Request{Query: `{title}`, Session: "ann"})
+	_, _ = root.ResolveRequestPolicy(&ggql.Request{Query: `{title book{bad}}`})
+	checkEqual(t, 3, cache.Len(), "public response")
+	query.Title = "Changed"
+	b.Reset()
+	result, _ = root.ResolveRequestPolicy(&ggql.Request{Query: `{title}`, Session: "bob"})
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, `{"data":{"title":"Books"}}`, b.String(), "shared public response")
+}
+
+func TestResponseCacheOptions(t *testing.T) {
+	root, query := setupCacheRoot(t)
+	cache := ggql.NewLRUResponseCache(10)
+	root.ResponseCache = cache
+
+	src := `{title book{title}}`
+	result, _ := root.ResolveRequestPolicy(&ggql.Request{Query: src})
+	_, ok := result["data"].(map[string]interface{})
+	checkEqual(t, true, ok, "default options data should be a map")
+
+	query.Title = "Changed"
+	result, _ = root.ResolveRequestPolicy(&ggql.Request{Query: src, Options: &ggql.Options{Ordered: true}})
+	checkEqual(t, 2, cache.Len(), "responses with other options are cached separately")
+	om, _ := result["data"].(*ggql.OrderedMap)
+	checkNotNil(t, om, "ordered data should be an OrderedMap")
+	title, _ := om.Get("title")
+	checkEqual(t, "Changed", title, "ordered response resolved again")
+
+	result, _ = root.ResolveRequestPolicy(&ggql.Request{Query: src, Options: &ggql.Options{Ordered: true}})
+	_, ok = result["data"].(*ggql.OrderedMap)
+	checkEqual(t, true, ok, "cached ordered data should be an OrderedMap")
+	checkEqual(t, 2, cache.Len(), "same options use the same response")
+}
+
+func TestResponseCacheCopy(t *testing.T) {
+	root, _ := setupCacheRoot(t)
+	root.ResponseCache = ggql.NewLRUResponseCache(10)
+
+	src := `{title books{title}}`
+	result, _ := root.ResolveRequestPolicy(&ggql.Request{Query: src})
+	data := result["data"].(map[string]interface{})
+	data["title"] = "Mutated"
+	data["books"].([]interface{})[0].(map[string]interface{})["title"] = "Mutated"
+
+	result, _ = root.ResolveRequestPolicy(&ggql.Request{Query: src})
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, `{"data":{"books":[{"title":"Leaves"}],"title":"Books"}}`, b.String(),
+		"changes to the first response should not change the cached response")
+
+	result["data"].(map[string]interface{})["title"] = "Mutated"
+	result, _ = root.ResolveRequestPolicy(&ggql.Request{Query: src})
+	b.Reset()
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, `{"data":{"books":[{"title":"Leaves"}],"title":"Books"}}`, b.String(),
+		"changes to a cached response should not change the next response")
+}
+
+func TestLRUResponseCache(t *testing.T) {
+	cache := ggql.NewLRUResponseCache(2)
+	policy := ggql.CachePolicy{MaxAge: 60, Scope: ggql.CachePublic}
+	cache.Put("a", map[string]interface{}{"data": 1}, policy)
+	cache.Put("b", map[string]interface{}{"data": 2}, policy)
+	cache.Put("none", map[string]interface{}{"data": 0}, ggql.CachePolicy{})
+	checkEqual(t, 2, cache.Len(), "zero max age is not cached")
+
+	r, _ := cache.Get("a")
+	checkEqual(t, 1, r["data"], "get a")
+	r["data"] = 4
+	r, _ = cache.Get("a")
+	checkEqual(t, 1, r["data"], "get a after modifying the response")
+	cache.Put("c", map[string]interface{}{"data": 3}, policy)
+	r, _ = cache.Get("b")
+	checkNil(t, r, "least recently used should be evicted")
+	r, p := cache.Get("c")
+	checkEqual(t, 3, r["data"], "get c")
+	checkEqual(t, 60, p.MaxAge, "max age")
+}
@@ -196,7 +196,7 @@ input Numbers {
   },
   "errors": [
     {
-      "message": "resolve error: can not coerce a string into a int at d",
+      "message": "resolve error: can not coerce a string into a int at d.0",
       "path": [
         "sum",
         "numbers"
@@ -19,8 +19,16 @@ const (
 	falseStr = "false"
 	nullStr  = "null"
 
+	spreadStr = "..."
+
+	oneOfStr          = "oneOf"
+	repeatableStr     = "repeatable"
+	specifiedByURLStr = "specifiedByURL"
+
 	argsStr              = "args"
 	booleanStr           = "Boolean"
+	cacheControlStr      = "cacheControl"
+	cacheScopeStr        = "CacheControlScope"
 	defaultValueStr      = "defaultValue"
 	deprecatedStr        = "deprecated"
 	deprecationReasonStr = "deprecationReason"
@@ -36,15 +44,21 @@ const (
 	interfaceStr         = "interface"
 	interfacesStr        = "interfaces"
 	isDeprecatedStr      = "isDeprecated"
+	isRepeatableStr      = "isRepeatable"
 	kindStr              = "kind"
 	locationsStr         = "locations"
+	maxAgeStr            = "maxAge"
 	nameStr              = "name"
 	ofTypeStr            = "ofType"
 	possibleTypesStr     = "possibleTypes"
+	queryTypeStr         = "Query"
 	reasonStr            = "reason"
 	scalarStr            = "scalar"
 	schemaStr            = "schema"
+	scopeStr             = "scope"
+	specifiedByStr       = "specifiedBy"
 	stringStr            = "String"
 	typeStr              = "type"
 	unionStr             = "union"
+	urlStr               = "url"
 )
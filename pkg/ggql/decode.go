@@ -0,0 +1,208 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textUnmarshalType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// DecodeArgs decodes the args provided to a resolver into the struct or map
+// pointed to by target. Argument names are matched to struct fields using
+// the graphql struct tag, then the json struct tag, and then a case
+// insensitive match on the field name. Nested input objects, lists, enum
+// values, Time, Int64, and custom scalar values are converted to the Go
+// types of the target fields. Enum values can be decoded into string types
+// or into any type that implements encoding.TextUnmarshaler. If a value can
+// not be decoded the returned error is an *Error with a Path to the
+// offending value.
+func DecodeArgs(args map[string]interface{}, target interface{}) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("%w: DecodeArgs target must be a non-nil pointer not a %T", ErrMeta, target)
+	}
+	if args == nil {
+		return nil
+	}
+	return decodeValue(rv.Elem(), args, true)
+}
+
+// decodeValue sets rv, which must be settable, to the value v converting
+// numbers, strings, lists, and input object maps to the Go type of rv as
+// needed. Map keys are matched to struct fields the same way the reflection
+// resolver matches GraphQL fields to Go fields. Errors include the path to
+// the element that could not be converted.
+func decodeValue(rv reflect.Value, v interface{}, withJSON bool) error {
+	if !rv.CanSet() {
+		return fmt.Errorf("%w: can not set a value of type %s", ErrMeta, rv.Type())
+	}
+	if v == nil {
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	}
+	vv := reflect.ValueOf(v)
+	if vv.Type().AssignableTo(rv.Type()) {
+		rv.Set(vv)
+		return nil
+	}
+	if rv.Type() == timeType {
+		tv, err := (*timeScalar)(nil).CoerceIn(v)
+		if err == nil {
+			rv.Set(reflect.ValueOf(tv))
+		}
+		return err
+	}
+	if vv.Kind() == reflect.String && rv.CanAddr() && rv.Addr().Type().Implements(textUnmarshalType) {
+		return rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(vv.String()))
+	}
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if vv.Kind() == reflect.Ptr {
+			if vv.IsNil() {
+				rv.Set(reflect.Zero(rv.Type()))
+				return nil
+			}
+			vv = vv.Elem()
+			v = vv.Interface()
+		}
+		ev := reflect.New(rv.Type().Elem())
+		if err := decodeValue(ev.Elem(), v, withJSON); err != nil {
+			return err
+		}
+		rv.Set(ev)
+		return nil
+	case reflect.Interface:
+		if vv.Type().Implements(rv.Type()) {
+			rv.Set(vv)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch vv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if !rv.OverflowInt(vv.Int()) {
+				rv.SetInt(vv.Int())
+				return nil
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if u := vv.Uint(); u <= 1<<63-1 && !rv.OverflowInt(int64(u)) {
+				rv.SetInt(int64(u))
+				return nil
+			}
+		case reflect.Float32, reflect.Float64:
+			if f := vv.Float(); f == float64(int64(f)) && !rv.OverflowInt(int64(f)) {
+				rv.SetInt(int64(f))
+				return nil
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch vv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if i := vv.Int(); 0 <= i && !rv.OverflowUint(uint64(i)) {
+				rv.SetUint(uint64(i))
+				return nil
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if !rv.OverflowUint(vv.Uint()) {
+				rv.SetUint(vv.Uint())
+				return nil
+			}
+		case reflect.Float32, reflect.Float64:
+			if f := vv.Float(); 0 <= f && f == float64(uint64(f)) && !rv.OverflowUint(uint64(f)) {
+				rv.SetUint(uint64(f))
+				return nil
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		switch vv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			rv.SetFloat(float64(vv.Int()))
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			rv.SetFloat(float64(vv.Uint()))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			rv.SetFloat(vv.Float())
+			return nil
+		}
+	case reflect.String:
+		if vv.Kind() == reflect.String { // string, Symbol, or other string types
+			rv.SetString(vv.String())
+			return nil
+		}
+	case reflect.Bool:
+		if vv.Kind() == reflect.Bool {
+			rv.SetBool(vv.Bool())
+			return nil
+		}
+	case reflect.Slice:
+		if vv.Kind() == reflect.Slice || vv.Kind() == reflect.Array {
+			n := vv.Len()
+			sv := reflect.MakeSlice(rv.Type(), n, n)
+			for i := 0; i < n; i++ {
+				if err := decodeValue(sv.Index(i), vv.Index(i).Interface(), withJSON); err != nil {
+					return inErr(err, i)
+				}
+			}
+			rv.Set(sv)
+			return nil
+		}
+	case reflect.Map:
+		if m, ok := v.(map[string]interface{}); ok && rv.Type().Key().Kind() == reflect.String {
+			mv := reflect.MakeMapWithSize(rv.Type(), len(m))
+			for k, x := range m {
+				ev := reflect.New(rv.Type().Elem()).Elem()
+				if err := decodeValue(ev, x, withJSON); err != nil {
+					return inErr(err, k)
+				}
+				mv.SetMapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()), ev)
+			}
+			rv.Set(mv)
+			return nil
+		}
+	case reflect.Struct:
+		switch {
+		case vv.Kind() == reflect.Ptr && vv.Type().Elem() == rv.Type():
+			if !vv.IsNil() {
+				rv.Set(vv.Elem())
+			}
+			return nil
+		case vv.Kind() == reflect.Map:
+			if m, ok := v.(map[string]interface{}); ok {
+				for k, x := range m {
+					sf, found := findGoField(rv.Type(), k, withJSON)
+					if !found {
+						return inErr(fmt.Errorf("%w: %s is not a field of %s", ErrMeta, k, rv.Type()), k)
+					}
+					if err := decodeValue(fieldByIndex(rv, sf.Index), x, withJSON); err != nil {
+						return inErr(err, k)
+					}
+				}
+				return nil
+			}
+		}
+	}
+	if vv.Type().ConvertibleTo(rv.Type()) && vv.Kind() == rv.Kind() {
+		rv.Set(vv.Convert(rv.Type()))
+		return nil
+	}
+	return fmt.Errorf("%w a %T into a %s", ErrCoerce, v, rv.Type())
+}
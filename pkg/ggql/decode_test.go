@@ -0,0 +1,276 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+const argSdl = `
+type Query {
+  add(x: Int!, y: Int = 2): Int
+  scale(x: Float, by: Int = 3): Float
+  span(range: Span): String
+  page(first: Int = 10, after: String): String
+  tags(list: [String]): String
+  few(x: Int): Int
+  many(x: Int, y: Int): Int
+  items(first: Int = 10): Int
+}
+
+input Span {
+  low: Int
+  high: Int = 9
+}
+`
+
+type ArgSchema struct {
+	Query *ArgQuery
+}
+
+type ArgQuery struct {
+}
+
+type ArgSpan struct {
+	Low  int
+	High int
+}
+
+type ArgPage struct {
+	First int
+	After string
+}
+
+func (q *ArgQuery) Add(x, y int) int {
+	return x + y
+}
+
+func (q *ArgQuery) Scale(x float64, by int32) float64 {
+	return x * float64(by)
+}
+
+func (q *ArgQuery) Span(r *ArgSpan) string {
+	if r == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%d-%d", r.Low, r.High)
+}
+
+func (q *ArgQuery) Page(p ArgPage) string {
+	return fmt.Sprintf("%d after %q", p.First, p.After)
+}
+
+func (q *ArgQuery) Tags(list []string) string {
+	return strings.Join(list, ",")
+}
+
+func (q *ArgQuery) Few(x, y int) int {
+	return x + y
+}
+
+func (q *ArgQuery) Many(x int) int {
+	return x
+}
+
+func (q *ArgQuery) Items() int {
+	return 3
+}
+
+func testArgs(t *testing.T, src, expect string) {
+	ggql.Sort = true
+	root := ggql.NewRoot(&ArgSchema{Query: &ArgQuery{}})
+	err := root.ParseString(argSdl)
+	checkNil(t, err, "no error should be returned when parsing a valid SDL. %s", err)
+	err = root.RegisterType(&ArgSpan{}, "Span")
+	checkNil(t, err, "RegisterType should not fail. %s", err)
+
+	result := root.ResolveString(src, "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, 2)
+	checkEqual(t, expect, b.String(), "result mismatch for %s", src)
+}
+
+func TestArgsDefault(t *testing.T) {
+	testArgs(t, `{add(x: 3) scale(x: 1.5)}`, `{
+  "data": {
+    "add": 5,
+    "scale": 4.5
+  }
+}
+`)
+}
+
+func TestArgsNamed(t *testing.T) {
+	testArgs(t, `{add(y: 4, x: 1)}`, `{
+  "data": {
+    "add": 5
+  }
+}
+`)
+}
+
+func TestArgsInput(t *testing.T) {
+	testArgs(t, `{span(range: {low: 2}) none: span}`, `{
+  "data": {
+    "none": "none",
+    "span": "2-9"
+  }
+}
+`)
+}
+
+func TestArgsStruct(t *testing.T) {
+	testArgs(t, `{page(after: "abc")}`, `{
+  "data": {
+    "page": "10 after \"abc\""
+  }
+}
+`)
+}
+
+func TestArgsList(t *testing.T) {
+	testArgs(t, `{tags(list: ["a", "b"])}`, `{
+  "data": {
+    "tags": "a,b"
+  }
+}
+`)
+}
+
+func TestArgsTooFew(t *testing.T) {
+	testArgs(t, `{few(x: 1)}`, `{
+  "data": {
+    "few": null
+  },
+  "errors": [
+    {
+      "locations": [
+        {
+          "column": 3,
+          "line": 1
+        }
+      ],
+      "message": "resolve error: reflection error: method for few takes 2 arguments but the field has 1",
+      "path": [
+        "few"
+      ]
+    }
+  ]
+}
+`)
+}
+
+func TestArgsUnused(t *testing.T) {
+	testArgs(t, `{many(x: 1, y: 2) items a: items(first: 2)}`, `{
+  "data": {
+    "a": 3,
+    "items": 3,
+    "many": 1
+  }
+}
+`)
+}
+
+type Level int
+
+func (l *Level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "LOW":
+		*l = 1
+	case "HIGH":
+		*l = 2
+	default:
+		return fmt.Errorf("%s is not a Level", text)
+	}
+	return nil
+}
+
+type Genre string
+
+type DecodeTrack struct {
+	Title  string `graphql:"name"`
+	Length int
+}
+
+type DecodeAlbum struct {
+	Title    string
+	Genre    Genre
+	Level    Level
+	Released time.Time
+	Plays    int64
+	Rating   *float32
+	Tracks   []*DecodeTrack
+	Extra    map[string]int
+}
+
+func TestDecodeArgs(t *testing.T) {
+	args := map[string]interface{}{
+		"title":    "Morningside",
+		"genre":    ggql.Symbol("POP"),
+		"level":    ggql.Symbol("HIGH"),
+		"released": "2018-05-25T00:00:00Z",
+		"plays":    int64(1234567890123),
+		"rating":   4.5,
+		"tracks": []interface{}{
+			map[string]interface{}{"name": "Lucky Girl", "length": int64(200)},
+			map[string]interface{}{"name": "Misread", "length": int32(183)},
+		},
+		"extra": map[string]interface{}{"likes": int64(7)},
+	}
+	var album DecodeAlbum
+	err := ggql.DecodeArgs(args, &album)
+	checkNil(t, err, "DecodeArgs should not fail. %s", err)
+	checkEqual(t, "Morningside", album.Title, "title")
+	checkEqual(t, "POP", string(album.Genre), "enum into a string type")
+	checkEqual(t, 2, int(album.Level), "enum into an int type")
+	checkEqual(t, "2018-05-25T00:00:00Z", album.Released.Format(time.RFC3339), "time")
+	checkEqual(t, int64(1234567890123), album.Plays, "int64")
+	checkNotNil(t, album.Rating, "rating should be set")
+	checkEqual(t, float32(4.5), *album.Rating, "rating")
+	checkEqual(t, 2, len(album.Tracks), "tracks")
+	checkEqual(t, "Misread", album.Tracks[1].Title, "tagged field in list element")
+	checkEqual(t, 183, album.Tracks[1].Length, "int32 into int")
+	checkEqual(t, 7, album.Extra["likes"], "map value")
+}
+
+func TestDecodeArgsError(t *testing.T) {
+	var album DecodeAlbum
+	err := ggql.DecodeArgs(map[string]interface{}{
+		"tracks": []interface{}{
+			map[string]interface{}{"name": "Lucky Girl"},
+			map[string]interface{}{"length": "long"},
+		},
+	}, &album)
+	checkNotNil(t, err, "DecodeArgs should fail on a bad value")
+	var gerr *ggql.Error
+	checkEqual(t, true, errors.As(err, &gerr), "error should be a *ggql.Error")
+	checkEqual(t, "[tracks 1 length]", fmt.Sprintf("%v", gerr.Path), "error path")
+	checkEqual(t, true, errors.Is(err, ggql.ErrCoerce), "error should be a coerce error")
+
+	err = ggql.DecodeArgs(map[string]interface{}{"level": "MEDIUM"}, &album)
+	checkNotNil(t, err, "DecodeArgs should fail on a bad enum value")
+
+	err = ggql.DecodeArgs(map[string]interface{}{"nothing": 1}, &album)
+	checkEqual(t, true, errors.Is(err, ggql.ErrMeta), "unknown field should be a meta error")
+
+	err = ggql.DecodeArgs(nil, album)
+	checkEqual(t, true, errors.Is(err, ggql.ErrMeta), "non-pointer target should be a meta error")
+}
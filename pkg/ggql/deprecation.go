@@ -0,0 +1,148 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func (b *Base) isDeprecated() bool {
+	return b.GetDirective(deprecatedStr) != nil
+}
+
+// deprecationReason returns the reason argument of the @deprecated directive
+// or nil if not deprecated or no reason was given.
+func (b *Base) deprecationReason() (reason interface{}) {
+	if du := b.GetDirective(deprecatedStr); du != nil {
+		if av := du.Args[reasonStr]; av != nil {
+			reason = av.Value
+		}
+	}
+	return
+}
+
+// withoutDeprecatedArgs returns the arguments that are not deprecated unless
+// includeDeprecated is true in which case all the arguments are returned.
+func (b *Base) withoutDeprecatedArgs(args map[string]interface{}, al *argList) *argList {
+	if b.getBoolArg(args, includeDeprecatedStr) {
+		return al
+	}
+	list := argList{dict: map[string]*Arg{}}
+	for _, a := range al.list {
+		if !a.isDeprecated() {
+			_ = list.add(a)
+		}
+	}
+	return &list
+}
+
+func depWarn(line, col int, what string, reason interface{}) error {
+	err := Error{Base: fmt.Errorf("%w, %s", ErrDeprecated, what), Line: line, Column: col}
+	// The default reason is double quoted for GraphiQL so strip the quotes.
+	if s, ok := reason.(string); ok {
+		err.Base = fmt.Errorf("%w: %s", err.Base, strings.Trim(s, `"`))
+	}
+	return &err
+}
+
+// deprecationWarnings returns a warning for each use of a deprecated
+// argument or input field in the operation.
+func (root *Root) deprecationWarnings(op *Op, vars map[string]interface{}) (warns []error) {
+	if fd := root.getFieldDef(root.schema, string(op.Type)); fd != nil {
+		warns = root.deprecatedInSels(op.Sels, fd.Type, vars, map[*Fragment]bool{})
+	}
+	return
+}
+
+func (root *Root) deprecatedInSels(
+	sels []Selection,
+	t Type,
+	vars map[string]interface{},
+	seen map[*Fragment]bool) (warns []error) {
+
+	t = BaseType(t)
+	for _, sel := range sels {
+		switch ts := sel.(type) {
+		case *Field:
+			fd := root.getFieldDef(t, ts.Name)
+			if fd == nil {
+				continue
+			}
+			for _, av := range ts.Args {
+				a := fd.getArg(av.Arg)
+				if a == nil {
+					continue
+				}
+				if a.isDeprecated() {
+					warns = append(warns, depWarn(av.line, av.col,
+						fmt.Sprintf("argument %s of %s", av.Arg, ts.Name), a.deprecationReason()))
+				}
+				warns = append(warns, deprecatedInValue(av.Value, a.Type, vars, av.line, av.col)...)
+			}
+			warns = append(warns, root.deprecatedInSels(ts.Sels, fd.Type, vars, seen)...)
+		case *Inline:
+			ct := t
+			if ts.Condition != nil {
+				ct = ts.Condition
+			}
+			warns = append(warns, root.deprecatedInSels(ts.Sels, ct, vars, seen)...)
+		case *FragRef:
+			if f := ts.Fragment; f != nil && !seen[f] {
+				seen[f] = true
+				ct := t
+				if f.Condition != nil {
+					ct = f.Condition
+				}
+				warns = append(warns, root.deprecatedInSels(f.Sels, ct, vars, seen)...)
+			}
+		}
+	}
+	return
+}
+
+func deprecatedInValue(v interface{}, t Type, vars map[string]interface{}, line, col int) (warns []error) {
+	switch tv := v.(type) {
+	case Var:
+		warns = deprecatedInValue(vars[string(tv)], t, vars, line, col)
+	case []interface{}:
+		for _, x := range tv {
+			warns = append(warns, deprecatedInValue(x, t, vars, line, col)...)
+		}
+	case map[string]interface{}:
+		it, _ := BaseType(t).(*Input)
+		if it == nil {
+			break
+		}
+		keys := make([]string, 0, len(tv))
+		for k := range tv {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			f := it.fields.get(k)
+			if f == nil {
+				continue
+			}
+			if f.isDeprecated() {
+				warns = append(warns, depWarn(line, col,
+					fmt.Sprintf("input field %s of %s", k, it.Name()), f.deprecationReason()))
+			}
+			warns = append(warns, deprecatedInValue(tv[k], f.Type, vars, line, col)...)
+		}
+	}
+	return
+}
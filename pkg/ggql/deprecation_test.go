@@ -0,0 +1,202 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+type depQuery struct{}
+
+func (q *depQuery) Find(name, old string) string {
+	return name + old
+}
+
+func (q *depQuery) Search(filter map[string]interface{}) int {
+	return len(filter)
+}
+
+type depSchema struct {
+	Query *depQuery
+}
+
+const depSDL = `
+type Query {
+  find(name: String, old: String @deprecated(reason: "use name")): String
+  search(filter: Filter): Int
+}
+input Filter {
+  name: String
+  tag: String @deprecated
+}
+`
+
+func setupDeprecated(t *testing.T) *ggql.Root {
+	ggql.Sort = true
+	root := ggql.NewRoot(&depSchema{Query: &depQuery{}})
+	err := root.ParseString(depSDL)
+	checkNil(t, err, "parse failed. %s", err)
+	return root
+}
+
+func TestDeprecatedIntrospection(t *testing.T) {
+	root := setupDeprecated(t)
+	result := root.ResolveString(`{
+  q: __type(name: "Query") {
+    fields {
+      name
+      args { name }
+      all: args(includeDeprecated: true) { name isDeprecated deprecationReason }
+    }
+  }
+  f: __type(name: "Filter") {
+    inputFields { name }
+    all: inputFields(includeDeprecated: true) { name isDeprecated deprecationReason }
+  }
+}`, "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, 2)
+	checkEqual(t, `{
+  "data": {
+    "f": {
+      "all": [
+        {
+          "deprecationReason": null,
+          "isDeprecated": false,
+          "name": "name"
+        },
+        {
+          "deprecationReason": "\"No longer supported\"",
+          "isDeprecated": true,
+          "name": "tag"
+        }
+      ],
+      "inputFields": [
+        {
+          "name": "name"
+        }
+      ]
+    },
+    "q": {
+      "fields": [
+        {
+          "all": [
+            {
+              "deprecationReason": null,
+              "isDeprecated": false,
+              "name": "name"
+            },
+            {
+              "deprecationReason": "use name",
+              "isDeprecated": true,
+              "name": "old"
+            }
+          ],
+          "args": [
+            {
+              "name": "name"
+            }
+          ],
+          "name": "find"
+        },
+        {
+          "all": [
+            {
+              "deprecationReason": null,
+              "isDeprecated": false,
+              "name": "filter"
+            }
+          ],
+          "args": [
+            {
+              "name": "filter"
+            }
+          ],
+          "name": "search"
+        }
+      ]
+    }
+  }
+}
+`, b.String(), "result mismatch")
+}
+
+func TestDeprecatedWarnings(t *testing.T) {
+	root := setupDeprecated(t)
+	src := `query($f: Filter) {
+  find(name: "a", old: "b")
+  search(filter: $f)
+}`
+	vars := map[string]interface{}{"f": map[string]interface{}{"name": "x", "tag": "y"}}
+	result := root.ResolveString(src, "", vars)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, 2)
+	checkEqual(t, `{
+  "data": {
+    "find": "ab",
+    "search": 2
+  }
+}
+`, b.String(), "result mismatch")
+
+	root.DeprecationWarnings = true
+	result = root.ResolveString(src, "", vars)
+	b.Reset()
+	_ = ggql.WriteJSONValue(&b, result, 2)
+	checkEqual(t, `{
+  "data": {
+    "find": "ab",
+    "search": 2
+  },
+  "extensions": {
+    "warnings": [
+      {
+        "locations": [
+          {
+            "column": 19,
+            "line": 2
+          }
+        ],
+        "message": "deprecated, argument old of find: use name"
+      },
+      {
+        "locations": [
+          {
+            "column": 10,
+            "line": 3
+          }
+        ],
+        "message": "deprecated, input field tag of Filter: No longer supported"
+      }
+    ]
+  }
+}
+`, b.String(), "result mismatch")
+}
+
+func TestDeprecatedRequired(t *testing.T) {
+	for _, sdl := range []string{
+		`type Query { find(name: String! @deprecated): String }`,
+		`type Query { search(filter: Filter): Int }
+input Filter { name: String! @deprecated }`,
+	} {
+		root := ggql.NewRoot(nil)
+		err := root.ParseString(sdl)
+		checkNotNil(t, err, "parse of %s should fail", sdl)
+	}
+}
@@ -30,6 +30,10 @@ type Directive struct {
 
 	// On the locations the directive can be used.
 	On []Location
+
+	// Repeatable if true allows the directive to be used more than once at
+	// the same location.
+	Repeatable bool
 }
 
 // Rank of the type.
@@ -56,7 +60,10 @@ func (t *Directive) Write(w io.Writer, desc bool) (err error) {
 	if err = writeDesc(w, t.Desc, 0, desc); err == nil {
 		if _, err = w.Write([]byte("directive @")); err == nil {
 			if _, err = w.Write([]byte(t.N)); err == nil {
-				if err = writeArgs(w, &t.args, desc); err == nil {
+				if err = writeArgs(w, &t.args, desc); err == nil && t.Repeatable {
+					_, err = w.Write([]byte(" " + repeatableStr))
+				}
+				if err == nil {
 					if _, err = w.Write([]byte(" on ")); err == nil {
 						for i, on := range t.On {
 							if 0 < i {
@@ -115,9 +122,7 @@ func (t *Directive) Validate(root *Root) (errs []error) {
 			errs = append(errs, fmt.Errorf("%w, directive %s argument %s, a %T is not an input type at %d:%d",
 				ErrValidation, t.Name(), a.Name(), a.Type, a.line, a.col))
 		}
-		for _, du := range a.Directives() {
-			errs = append(errs, root.validateDirUse(t.Name()+"."+a.Name(), Locate(a), du)...)
-		}
+		errs = append(errs, root.validateDirUseList(t.Name()+"."+a.Name(), Locate(a), a.Directives())...)
 	}
 	if path := t.hasDirLoop(map[string]bool{t.Name(): true}); 0 < len(path) {
 		errs = append(errs, fmt.Errorf("%w, directive %s has a directive loop - %s at %d:%d",
@@ -149,6 +154,7 @@ func (t *Directive) hasDirLoop(hits map[string]bool) []string {
 //   description: String
 //   locations: [__DirectiveLocation!]!
 //   args: [__InputValue!]!
+//   isRepeatable: Boolean!
 func (t *Directive) Resolve(field *Field, args map[string]interface{}) (result interface{}, err error) {
 	switch field.Name {
 	case nameStr:
@@ -162,7 +168,9 @@ func (t *Directive) Resolve(field *Field, args map[string]interface{}) (result i
 		}
 		result = list
 	case argsStr:
-		result = &t.args
+		result = t.withoutDeprecatedArgs(args, &t.args)
+	case isRepeatableStr:
+		result = t.Repeatable
 	}
 	return
 }
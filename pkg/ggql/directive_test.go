@@ -15,6 +15,7 @@
 package ggql_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/uhn/ggql/pkg/ggql"
@@ -84,3 +85,40 @@ func TestDirectiveBadRef(t *testing.T) {
 	err = root.AddTypes(&dir)
 	checkNotNil(t, err, "root.AddTypes with bad arg directive ref should fail.")
 }
+
+type idQuery struct {
+	ID string
+}
+
+type idSchema struct {
+	Query *idQuery
+}
+
+func TestDirectiveRepeatable(t *testing.T) {
+	ggql.Sort = true
+	root := ggql.NewRoot(&idSchema{Query: &idQuery{}})
+	err := root.ParseString(`
+type Query @tag(name: "a") @tag(name: "b") { id: ID }
+directive @tag(name: String) repeatable on OBJECT
+`)
+	checkNil(t, err, "no error should be returned when parsing a valid SDL. %s", err)
+
+	checkEqual(t, true, strings.Contains(root.SDL(false), "directive @tag(name: String) repeatable on OBJECT"),
+		"repeatable SDL. %s", root.SDL(false))
+
+	result := root.ResolveString(`{__schema{directives{name isRepeatable}}}`, "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, true, strings.Contains(b.String(), `{"isRepeatable":true,"name":"tag"}`),
+		"tag should be repeatable in introspection. %s", b.String())
+	checkEqual(t, true, strings.Contains(b.String(), `{"isRepeatable":false,"name":"skip"}`),
+		"skip should not be repeatable in introspection. %s", b.String())
+
+	root = ggql.NewRoot(nil)
+	err = root.ParseString(`
+type Query @once @once { id: ID }
+directive @once on OBJECT
+`)
+	checkNotNil(t, err, "a non-repeatable directive used twice should fail")
+	checkEqual(t, true, strings.Contains(err.Error(), "@once is not repeatable"), "error message. %s", err)
+}
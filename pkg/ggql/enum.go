@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"reflect"
 )
 
 // Enum is a GraphQL Enum.
@@ -26,6 +27,12 @@ type Enum struct {
 
 	// Values of the enum.
 	values enumValueList
+
+	// Go type and value mappings set with Root.RegisterEnum() or
+	// Root.RegisterEnumValues().
+	meta   reflect.Type
+	toGo   map[Symbol]interface{}
+	fromGo map[interface{}]Symbol
 }
 
 // Rank of the type.
@@ -96,9 +103,7 @@ func (t *Enum) Validate(root *Root) (errs []error) {
 			default:
 				errs = append(errs, validateName(t.core, "enum value", string(ev.Value), ev.line, ev.col)...)
 			}
-			for _, du := range ev.Directives {
-				errs = append(errs, root.validateDirUse(t.Name()+"."+string(ev.Value), Locate(ev), du)...)
-			}
+			errs = append(errs, root.validateDirUseList(t.Name()+"."+string(ev.Value), Locate(ev), ev.Directives)...)
 		}
 	} else {
 		errs = append(errs, fmt.Errorf("%w, enum %s must have at least one value at %d:%d",
@@ -107,29 +112,89 @@ func (t *Enum) Validate(root *Root) (errs []error) {
 	return
 }
 
+// bind maps each of the named enum values to the Go value at the same
+// index. All the values are checked before any are mapped so the enum is
+// left unchanged if an error is returned.
+func (t *Enum) bind(names []string, values []interface{}) error {
+	meta := t.meta
+	for i, name := range names {
+		if !t.values.has(Symbol(name)) {
+			return fmt.Errorf("%w: %s is not a value of enum %s", ErrMeta, name, t.N)
+		}
+		vt := reflect.TypeOf(values[i])
+		switch {
+		case vt == nil || !vt.Comparable():
+			return fmt.Errorf("%w: a %T can not be mapped to enum %s", ErrMeta, values[i], t.N)
+		case meta != nil && meta != vt:
+			return fmt.Errorf("%w: %s is already registered as a %s", ErrDuplicate, t.N, meta)
+		}
+		meta = vt
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	if t.toGo == nil {
+		t.meta = meta
+		t.toGo = map[Symbol]interface{}{}
+		t.fromGo = map[interface{}]Symbol{}
+	}
+	for i, name := range names {
+		t.toGo[Symbol(name)] = values[i]
+		t.fromGo[values[i]] = Symbol(name)
+	}
+	return nil
+}
+
 // CoerceIn coerces an input value into the expected input type if possible
-// otherwise an error is returned.
+// otherwise an error is returned. If the enum has been registered with a Go
+// type the Go value for the enum value is returned.
+// The package Relaxed variable determines if the relaxed coercion rules
+// are used. Requests use the Relaxed option of the Root instead.
 func (t *Enum) CoerceIn(v interface{}) (interface{}, error) {
+	return t.coerceIn(v, Relaxed)
+}
+
+func (t *Enum) coerceIn(v interface{}, relaxed bool) (interface{}, error) {
 	if v == nil {
 		return nil, nil
 	}
-	if s, ok := v.(Symbol); ok {
-		if t.values.has(s) {
+	if t.meta != nil && reflect.TypeOf(v) == t.meta {
+		return v, nil
+	}
+	s, ok := v.(Symbol)
+	if !ok {
+		str, isStr := v.(string)
+		if !isStr || !relaxed {
+			return nil, newCoerceErr(v, t.N)
+		}
+		s = Symbol(str)
+		if t.toGo == nil {
 			return s, nil
 		}
+	}
+	if !t.values.has(s) {
 		return nil, fmt.Errorf("%s is not a valid enum value in %s", s, t.Name())
 	}
-	if Relaxed {
-		if s, ok := v.(string); ok {
-			return Symbol(s), nil
+	if t.toGo != nil {
+		gv, has := t.toGo[s]
+		if !has {
+			return nil, fmt.Errorf("%w %s into a %s, enum value not mapped", ErrCoerce, s, t.meta)
 		}
+		return gv, nil
 	}
-	return nil, newCoerceErr(v, t.N)
+	return s, nil
 }
 
-// CoerceOut coerces a result value into a type for the scalar.
+// CoerceOut coerces a result value into a type for the scalar. Values of a
+// Go type registered with the enum are converted to the enum value name.
 func (t *Enum) CoerceOut(v interface{}) (interface{}, error) {
 	var err error
+	if t.meta != nil && reflect.TypeOf(v) == t.meta {
+		if s, has := t.fromGo[v]; has {
+			return string(s), nil
+		}
+		return nil, fmt.Errorf("%w %v into a %s, Go value not mapped", ErrCoerce, v, t.N)
+	}
 	switch tv := v.(type) {
 	case nil:
 		// remains nil
@@ -154,6 +219,7 @@ func (t *Enum) CoerceOut(v interface{}) (interface{}, error) {
 //   enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
 //   inputfields: [__InputValue!]
 //   ofType: __Type
+//   specifiedByURL: String
 func (t *Enum) Resolve(field *Field, args map[string]interface{}) (result interface{}, err error) {
 	switch field.Name {
 	case kindStr:
@@ -174,7 +240,7 @@ func (t *Enum) Resolve(field *Field, args map[string]interface{}) (result interf
 			}
 			result = &list
 		}
-	case possibleTypesStr, fieldsStr, interfacesStr, inputFieldsStr, ofTypeStr:
+	case possibleTypesStr, fieldsStr, interfacesStr, inputFieldsStr, ofTypeStr, specifiedByURLStr:
 		// nil result
 	}
 	return
@@ -15,6 +15,8 @@
 package ggql_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/uhn/ggql/pkg/ggql"
@@ -163,3 +165,140 @@ func TestEnumValues(t *testing.T) {
 	enum := ggql.Enum{}
 	checkEqual(t, 0, len(enum.Values()), "Enum values should be empty")
 }
+
+const bindEnumSdl = `
+type Query {
+  genre(g: Genre = POP): Genre
+  genres(list: [Genre]): [Genre]
+  odd: Genre
+  mood(m: Mood): String
+}
+
+enum Genre {
+  POP
+  ROCK
+  JAZZ
+}
+
+enum Mood {
+  HAPPY
+  SAD
+}
+`
+
+type BindGenre int
+
+const (
+	BindPop BindGenre = iota
+	BindRock
+	BindJazz
+	BindPolka
+)
+
+func (g BindGenre) String() string {
+	return [...]string{"POP", "ROCK", "JAZZ", "POLKA"}[g]
+}
+
+type BindMood string
+
+type BindSchema struct {
+	Query *BindQuery
+}
+
+type BindQuery struct {
+}
+
+func (q *BindQuery) Genre(g BindGenre) BindGenre {
+	return g
+}
+
+func (q *BindQuery) Genres(list []BindGenre) []BindGenre {
+	return list
+}
+
+func (q *BindQuery) Odd() BindGenre {
+	return BindPolka
+}
+
+func (q *BindQuery) Mood(m BindMood) string {
+	return fmt.Sprintf("%T %s", m, m)
+}
+
+func setupBindEnum(t *testing.T) *ggql.Root {
+	ggql.Sort = true
+	root := ggql.NewRoot(&BindSchema{Query: &BindQuery{}})
+	err := root.ParseString(bindEnumSdl)
+	checkNil(t, err, "no error should be returned when parsing a valid SDL. %s", err)
+	err = root.RegisterEnum("Genre", BindPop, BindRock, BindJazz)
+	checkNil(t, err, "RegisterEnum should not fail. %s", err)
+	err = root.RegisterEnumValues("Mood", map[string]interface{}{"HAPPY": BindMood("happy"), "SAD": BindMood("sad")})
+	checkNil(t, err, "RegisterEnumValues should not fail. %s", err)
+
+	return root
+}
+
+func TestEnumBind(t *testing.T) {
+	root := setupBindEnum(t)
+
+	result := root.ResolveString(
+		`query($g: Genre){genre(g: ROCK) def: genre v: genre(g: $g) genres(list: [JAZZ, POP]) mood(m: SAD)}`,
+		"", map[string]interface{}{"g": ggql.Symbol("JAZZ")})
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, 2)
+	checkEqual(t, `{
+  "data": {
+    "def": "POP",
+    "genre": "ROCK",
+    "genres": [
+      "JAZZ",
+      "POP"
+    ],
+    "mood": "ggql_test.BindMood sad",
+    "v": "JAZZ"
+  }
+}
+`, b.String(), "result should match")
+}
+
+func TestEnumBindUnmapped(t *testing.T) {
+	root := setupBindEnum(t)
+
+	result := root.ResolveString(`{odd}`, "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, 2)
+	checkEqual(t, true, strings.Contains(b.String(), "can not coerce POLKA into a Genre, Go value not mapped"),
+		"unmapped Go value should be an error. %s", b.String())
+}
+
+func TestEnumBindError(t *testing.T) {
+	root := setupBindEnum(t)
+
+	err := root.RegisterEnum("Genre", BindPolka)
+	checkNotNil(t, err, "RegisterEnum with a value not in the enum should fail")
+
+	err = root.RegisterEnumValues("Genre", map[string]interface{}{"POP": "pop"})
+	checkNotNil(t, err, "RegisterEnumValues with a different Go type should fail")
+
+	err = root.RegisterEnum("Query", BindPop)
+	checkNotNil(t, err, "RegisterEnum on a non enum type should fail")
+}
+
+func TestEnumBindAtomic(t *testing.T) {
+	root := ggql.NewRoot(&BindSchema{Query: &BindQuery{}})
+	err := root.ParseString(bindEnumSdl)
+	checkNil(t, err, "no error should be returned when parsing a valid SDL. %s", err)
+
+	err = root.RegisterEnum("Genre", BindPop, BindPolka)
+	checkNotNil(t, err, "RegisterEnum with a value not in the enum should fail")
+	err = root.RegisterEnumValues("Genre", map[string]interface{}{"POP": "pop"})
+	checkNil(t, err, "a failed RegisterEnum should not register any values. %s", err)
+
+	err = root.RegisterEnumValues("Mood", map[string]interface{}{"HAPPY": BindMood("happy"), "SAD": 2})
+	checkNotNil(t, err, "RegisterEnumValues with mixed Go types should fail")
+
+	result := root.ResolveString(`{mood(m: HAPPY)}`, "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, `{"data":{"mood":"ggql_test.BindMood HAPPY"}}`, b.String(),
+		"a failed RegisterEnumValues should not register any values")
+}
@@ -37,6 +37,16 @@ type EnumValue struct {
 	col  int
 }
 
+// Line the value was defined on in the schema.
+func (ev *EnumValue) Line() int {
+	return ev.line
+}
+
+// Column the value was defined on in the schema.
+func (ev *EnumValue) Column() int {
+	return ev.col
+}
+
 // Write the type as SDL.
 func (ev *EnumValue) Write(w io.Writer, desc bool) (err error) {
 	if err = writeDesc(w, ev.Description, 1, desc); err == nil {
@@ -45,8 +45,87 @@ var (
 
 	// ErrMeta indicates an error with a type or field registration.
 	ErrMeta = errors.New("reflection error")
+
+	// ErrDeprecated indicates a deprecated argument or input field was used.
+	ErrDeprecated = errors.New("deprecated")
+
+	// ErrPersistedQueryNotFound indicates a persisted query hash is not in
+	// the store. The message is the one expected by the automatic persisted
+	// query protocol.
+	ErrPersistedQueryNotFound = errors.New("PersistedQueryNotFound")
+
+	// ErrPersistedQuery indicates a persisted query request is not valid or
+	// not allowed.
+	ErrPersistedQuery = errors.New("persisted query error")
+
+	// ErrPanic indicates a panic was recovered while resolving a field.
+	ErrPanic = errors.New("panic")
+)
+
+// Codes set as the "code" extension of errors when Root.ErrorCodes is true.
+const (
+	// CodeParseFailed is the code for ErrParse errors.
+	CodeParseFailed = "GRAPHQL_PARSE_FAILED"
+
+	// CodeValidationFailed is the code for ErrValidation errors.
+	CodeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+
+	// CodeBadUserInput is the code for ErrCoerce errors and for variable
+	// and argument values that could not be coerced when resolving.
+	CodeBadUserInput = "BAD_USER_INPUT"
+
+	// CodeResolveFailed is the code for ErrResolve errors including errors
+	// returned by resolvers.
+	CodeResolveFailed = "RESOLVE_FAILED"
+
+	// CodePersistedQueryNotFound is the code for ErrPersistedQueryNotFound
+	// errors.
+	CodePersistedQueryNotFound = "PERSISTED_QUERY_NOT_FOUND"
+
+	// CodePersistedQueryNotAllowed is the code for a query that is not in
+	// the persisted query store when only persisted queries are allowed.
+	CodePersistedQueryNotAllowed = "PERSISTED_QUERY_NOT_ALLOWED"
+
+	// CodePersistedQueryNotSupported is the code for an unsupported
+	// persisted query version.
+	CodePersistedQueryNotSupported = "PERSISTED_QUERY_NOT_SUPPORTED"
+
+	// CodeInvalidPersistedQueryHash is the code for a persisted query hash
+	// that does not match the query.
+	CodeInvalidPersistedQueryHash = "INVALID_PERSISTED_QUERY_HASH"
+
+	// CodeInternalServerError is the code for all other errors.
+	CodeInternalServerError = "INTERNAL_SERVER_ERROR"
 )
 
+// ErrorCode returns the code for an error. If the error is an Error with a
+// "code" extension that code is returned otherwise the code is determined
+// by the base error such as CodeParseFailed for an ErrParse error.
+func ErrorCode(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		if code, _ := e.Extensions["code"].(string); 0 < len(code) {
+			return code
+		}
+		if 0 < len(e.code) {
+			return e.code
+		}
+	}
+	switch {
+	case errors.Is(err, ErrParse):
+		return CodeParseFailed
+	case errors.Is(err, ErrValidation):
+		return CodeValidationFailed
+	case errors.Is(err, ErrCoerce):
+		return CodeBadUserInput
+	case errors.Is(err, ErrPersistedQueryNotFound):
+		return CodePersistedQueryNotFound
+	case errors.Is(err, ErrResolve):
+		return CodeResolveFailed
+	}
+	return CodeInternalServerError
+}
+
 func newCoerceErr(val interface{}, typeName string) error {
 	if IsNil(val) {
 		return fmt.Errorf("%w null into a %s", ErrCoerce, typeName)
@@ -97,13 +176,17 @@ type Error struct {
 
 	// Path to the element in the response that the error applies to. If empty
 	// or nil then it does not apply to the response data or that
-	// determination could not be made.
+	// determination could not be made. When returned from a resolver the
+	// path is relative to the field being resolved.
 	Path []interface{}
 
 	// Extensions is reserved for implementors to add additional information to
 	// errors however they see fit, and there are no additional restrictions on
 	// its contents.
 	Extensions map[string]interface{}
+
+	// code is used by ErrorCode() when there is no code extension.
+	code string
 }
 
 // Error returns a string representation of the error.
@@ -126,6 +209,12 @@ func (err *Error) Error() string {
 	return b.String()
 }
 
+// Unwrap returns the Base error so that errors.Is and errors.As can be used
+// to check the underlying error.
+func (err *Error) Unwrap() error {
+	return err.Base
+}
+
 func (err *Error) in(loc interface{}) {
 	err.Path = append([]interface{}{loc}, err.Path...)
 }
@@ -166,6 +255,15 @@ func resWarn(line, col int, format string, args ...interface{}) error {
 	}
 }
 
+// inputError marks an error as a BAD_USER_INPUT error if it is an Error.
+func inputError(err error) error {
+	var e *Error
+	if errors.As(err, &e) {
+		e.code = CodeBadUserInput
+	}
+	return err
+}
+
 func resWarnp(sel Selection, format string, args ...interface{}) error {
 	pa := []interface{}{}
 	if f, _ := sel.(*Field); f != nil {
@@ -15,7 +15,9 @@
 package ggql_test
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/uhn/ggql/pkg/ggql"
@@ -32,3 +34,84 @@ func TestErrors(t *testing.T) {
 }
 `, err.Error(), "expect multiple lines")
 }
+
+func TestErrorCode(t *testing.T) {
+	for _, v := range []struct {
+		err  error
+		code string
+	}{
+		{err: fmt.Errorf("%w: bad", ggql.ErrParse), code: ggql.CodeParseFailed},
+		{err: &ggql.Error{Base: fmt.Errorf("%w: bad", ggql.ErrValidation)}, code: ggql.CodeValidationFailed},
+		{err: fmt.Errorf("%w a string into an Int", ggql.ErrCoerce), code: ggql.CodeBadUserInput},
+		{err: fmt.Errorf("%w: failed", ggql.ErrResolve), code: ggql.CodeResolveFailed},
+		{err: ggql.ErrPersistedQueryNotFound, code: ggql.CodePersistedQueryNotFound},
+		{err: fmt.Errorf("%w: no method", ggql.ErrMeta), code: ggql.CodeInternalServerError},
+		{err: errors.New("other"), code: ggql.CodeInternalServerError},
+		{
+			err:  &ggql.Error{Base: ggql.ErrResolve, Extensions: map[string]interface{}{"code": "CUSTOM"}},
+			code: "CUSTOM",
+		},
+	} {
+		checkEqual(t, v.code, ggql.ErrorCode(v.err), "code for %s", v.err)
+	}
+}
+
+func TestErrorCodesInResponse(t *testing.T) {
+	root := setupTestSongs(t, &strings.Builder{})
+	root.ErrorCodes = true
+	for _, v := range []struct {
+		src    string
+		vars   map[string]interface{}
+		expect string
+	}{
+		{src: `{artists{name}`, expect: ggql.CodeParseFailed},
+		{src: `{artist(name: "a", name: "b"){name}}`, expect: ggql.CodeValidationFailed},
+		{src: `{artist(name:""){name}}`, expect: ggql.CodeResolveFailed},
+		{
+			src:    `query($w: Int!){options(misc:{width: $w})}`,
+			vars:   map[string]interface{}{"w": "wide"},
+			expect: ggql.CodeBadUserInput,
+		},
+	} {
+		result := root.ResolveString(v.src, "", v.vars)
+		errs, _ := result["errors"].([]interface{})
+		checkEqual(t, true, 0 < len(errs), "an error expected for %s", v.src)
+		em, _ := errs[0].(map[string]interface{})
+		ext, _ := em["extensions"].(map[string]interface{})
+		checkEqual(t, v.expect, ext["code"], "code for %s", v.src)
+	}
+}
+
+func TestErrorPresenter(t *testing.T) {
+	root := setupTestSongs(t, &strings.Builder{})
+	root.ErrorCodes = true
+	root.ErrorPresenter = func(err *ggql.Error) *ggql.Error {
+		if ggql.ErrorCode(err) == ggql.CodeResolveFailed {
+			err.Base = errors.New("internal error")
+		}
+		return err
+	}
+	src := `{artist(name:""){name} title}`
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, root.ResolveString(src, "", nil), -1)
+	checkEqual(t, `{"data":{"artist":null,"title":"Songs"},"errors":[{"extensions":{"code":"RESOLVE_FAILED"},`+
+		`"locations":[{"column":3,"line":1}],"message":"internal error","path":["artist"]}]}`,
+		b.String(), "presented error")
+
+	b.Reset()
+	err := root.StreamString(&b, src, "", nil)
+	checkNil(t, err, "stream failed. %s", err)
+	checkEqual(t, `{"data":{"artist":null,"title":"Songs"},"errors":[{"extensions":{"code":"RESOLVE_FAILED"},`+
+		`"locations":[{"column":3,"line":1}],"message":"internal error","path":["artist"]}]}`,
+		b.String(), "presented streamed error")
+
+	root.ErrorPresenter = func(err *ggql.Error) *ggql.Error {
+		return nil
+	}
+	b.Reset()
+	_ = ggql.WriteJSONValue(&b, root.ResolveString(src, "", nil), -1)
+	checkEqual(t, `{"data":{"artist":null,"title":"Songs"}}`, b.String(), "dropped errors")
+
+	list := root.FormErrors(errors.New("plain"))
+	checkEqual(t, 0, len(list), "presenter should drop plain errors too")
+}
@@ -0,0 +1,86 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql
+
+import (
+	"container/list"
+	"sync"
+)
+
+// exeCache is a least recently used cache of plans compiled from parsed and
+// validated executables keyed by the hash of the document and the operation
+// name. Plans are not modified when evaluated so they can be shared by
+// concurrent requests.
+type exeCache struct {
+	mu      sync.Mutex
+	types   *typeList
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type exeCacheEntry struct {
+	key  string
+	plan *Plan
+}
+
+// get returns the cached plan for the key or nil if not in the
+// cache. The types argument is the current schema type list. Any change to
+// the schema replaces the type list so a mismatch clears the cache.
+func (c *exeCache) get(types *typeList, key string) (plan *Plan) {
+	c.mu.Lock()
+	c.check(types)
+	if e := c.entries[key]; e != nil {
+		c.order.MoveToFront(e)
+		plan = e.Value.(*exeCacheEntry).plan
+	}
+	c.mu.Unlock()
+
+	return
+}
+
+// put adds a plan to the cache and then evicts the least recently
+// used entries until no more than max remain.
+func (c *exeCache) put(types *typeList, key string, plan *Plan, max int) {
+	c.mu.Lock()
+	c.check(types)
+	if e := c.entries[key]; e != nil {
+		e.Value.(*exeCacheEntry).plan = plan
+		c.order.MoveToFront(e)
+	} else {
+		c.entries[key] = c.order.PushFront(&exeCacheEntry{key: key, plan: plan})
+	}
+	for max < c.order.Len() {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*exeCacheEntry).key)
+	}
+	c.mu.Unlock()
+}
+
+func (c *exeCache) check(types *typeList) {
+	if c.entries == nil || c.types != types {
+		c.types = types
+		c.order = list.New()
+		c.entries = map[string]*list.Element{}
+	}
+}
+
+// clear removes all the cached plans. Plans hold the reflection accessors
+// for fields so the cache is cleared when a Go type or field is registered.
+func (c *exeCache) clear() {
+	c.mu.Lock()
+	c.entries = nil
+	c.mu.Unlock()
+}
@@ -0,0 +1,188 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+func TestExecutableCache(t *testing.T) {
+	var log strings.Builder
+	root := setupTestSongs(t, &log)
+	root.ExecutableCacheSize = 2
+
+	for _, q := range []struct {
+		src    string
+		vars   map[string]interface{}
+		expect string
+	}{
+		{
+			src:    `query find($name: String!){artist(name: $name){name}}`,
+			vars:   map[string]interface{}{"name": "Fazerdaze"},
+			expect: `{"data":{"artist":{"name":"Fazerdaze"}}}`,
+		},
+		{
+			src:    `query find($name: String!){artist(name: $name){name}}`,
+			vars:   map[string]interface{}{"name": "Viagra Boys"},
+			expect: `{"data":{"artist":{"name":"Viagra Boys"}}}`,
+		},
+		{
+			src:    `{artist(name: "Fazerdaze", x: 1){name}}`,
+			expect: `{"data":{},"errors":[{"locations":[{"column":28,"line":1}],"message":"validation: x is not an argument to artist","path":["artist"]}]}`,
+		},
+		{
+			// The argument error must be reported again for a cached
+			// executable.
+			src:    `{artist(name: "Fazerdaze", x: 1){name}}`,
+			expect: `{"data":{},"errors":[{"locations":[{"column":28,"line":1}],"message":"validation: x is not an argument to artist","path":["artist"]}]}`,
+		},
+		{
+			src:    `{title}`,
+			expect: `{"data":{"title":"Songs"}}`,
+		},
+		{
+			// Evicted by now so parsed again.
+			src:    `query find($name: String!){artist(name: $name){name}}`,
+			vars:   map[string]interface{}{"name": "Fazerdaze"},
+			expect: `{"data":{"artist":{"name":"Fazerdaze"}}}`,
+		},
+	} {
+		var b strings.Builder
+		_ = ggql.WriteJSONValue(&b, root.ResolveString(q.src, "", q.vars), -1)
+		checkEqual(t, q.expect, b.String(), "%s result mismatch", q.src)
+	}
+}
+
+func TestExecutableCacheOperations(t *testing.T) {
+	var log strings.Builder
+	root := setupTestSongs(t, &log)
+	root.ExecutableCacheSize = 10
+
+	// Each operation of a document is cached as a separate plan.
+	src := `query a {title} query b {artist(name: "Fazerdaze"){name}}`
+	for _, q := range []struct {
+		op     string
+		expect string
+	}{
+		{op: "a", expect: `{"data":{"title":"Songs"}}`},
+		{op: "b", expect: `{"data":{"artist":{"name":"Fazerdaze"}}}`},
+		{op: "a", expect: `{"data":{"title":"Songs"}}`},
+	} {
+		var b strings.Builder
+		_ = ggql.WriteJSONValue(&b, root.ResolveString(src, q.op, nil), -1)
+		checkEqual(t, q.expect, b.String(), "operation %s result mismatch", q.op)
+	}
+}
+
+func TestExecutableCacheInputVars(t *testing.T) {
+	var log strings.Builder
+	root := setupTestSongs(t, &log)
+	root.ExecutableCacheSize = 10
+
+	src := `query opt($w: Int!){options(misc: {width: $w, sizes: [$w]})}`
+	for _, w := range []int{1, 2} {
+		var b strings.Builder
+		_ = ggql.WriteJSONValue(&b, root.ResolveString(src, "", map[string]interface{}{"w": w}), -1)
+		checkEqual(t, fmt.Sprintf(`{"data":{"options":"{misc: {sizes: [%d], width: %d}}"}}`, w, w), b.String(),
+			"width %d result mismatch", w)
+	}
+}
+
+func TestExecutableCacheSchemaChange(t *testing.T) {
+	var log strings.Builder
+	root := setupTestSongs(t, &log)
+	root.ExecutableCacheSize = 10
+
+	src := `{artist(name: "Fazerdaze"){... on Extra {name}}}`
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, root.ResolveString(src, "", nil), -1)
+	checkEqual(t, `{"errors":[{"locations":[{"column":35,"line":1}],"message":"parse error: type Extra not defined"}]}`,
+		b.String(), "before schema change")
+
+	err := root.ParseString("type Extra {name: String}")
+	checkNil(t, err, "parse failed. %s", err)
+
+	b.Reset()
+	_ = ggql.WriteJSONValue(&b, root.ResolveString(src, "", nil), -1)
+	checkEqual(t, `{"data":{"artist":{}}}`, b.String(), "after schema change")
+}
+
+func TestExecutableCacheRegisterField(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	root.ExecutableCacheSize = 10
+
+	src := `{artist(name: "Fazerdaze"){songs{name duration}}}`
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, root.ResolveString(src, "", nil), -1)
+	checkEqual(t, `{"data":{"artist":{"songs":[{"duration":240,"name":"Jennifer"},{"duration":170,"name":"Lucky Girl"},{"duration":194,"name":"Friends"},{"duration":193,"name":"Reel"}]}}}`,
+		b.String(), "before register")
+
+	err := root.RegisterField("Song", "duration", "Likes")
+	checkNil(t, err, "RegisterField should not fail. %s", err)
+
+	b.Reset()
+	_ = ggql.WriteJSONValue(&b, root.ResolveString(src, "", nil), -1)
+	checkEqual(t, `{"data":{"artist":{"songs":[{"duration":0,"name":"Jennifer"},{"duration":0,"name":"Lucky Girl"},{"duration":0,"name":"Friends"},{"duration":0,"name":"Reel"}]}}}`,
+		b.String(), "after register")
+}
+
+func TestExecutableCacheConcurrent(t *testing.T) {
+	var log strings.Builder
+	root := setupTestSongs(t, &log)
+	root.ExecutableCacheSize = 1
+
+	src := `{artists{name songs{name}} artist(name: "Fazerdaze"){origin}}`
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, root.ResolveString(src, "", nil), -1)
+	expect := b.String()
+
+	var wg sync.WaitGroup
+	results := make([]string, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var b strings.Builder
+			_ = ggql.WriteJSONValue(&b, root.ResolveString(src, "", nil), -1)
+			results[i] = b.String()
+		}(i)
+	}
+	wg.Wait()
+	for _, r := range results {
+		checkEqual(t, expect, r, "concurrent result mismatch")
+	}
+}
+
+func TestResolveExecutableUnmodified(t *testing.T) {
+	var log strings.Builder
+	root := setupTestSongs(t, &log)
+
+	exe, err := root.ParseExecutableString(`{artist(name: "Fazerdaze"){name}}`)
+	checkNil(t, err, "parse failed. %s", err)
+	before := exe.String()
+	for i := 0; i < 2; i++ {
+		_, err = root.ResolveExecutable(exe, "", nil)
+		checkNil(t, err, "resolve failed. %s", err)
+	}
+	checkEqual(t, before, exe.String(), "executable should not be modified")
+	field, _ := exe.Ops[""].Sels[0].(*ggql.Field)
+	checkNotNil(t, field, "expected a field")
+	checkEqual(t, true, field.ConType == nil, "ConType should not be set on the executable")
+}
@@ -62,9 +62,7 @@ func (f *Field) Validate(root *Root) (errs []error) {
 		}
 		dups[av.Arg] = true
 	}
-	for _, du := range f.Directives() {
-		errs = append(errs, root.validateDirUse(f.Name, Locate(f), du)...)
-	}
+	errs = append(errs, root.validateDirUseList(f.Name, Locate(f), f.Directives())...)
 	// Additional argument checks are performed during the resolve phase so no
 	// need to attempt to validate argument type matching and coerce success.
 	return
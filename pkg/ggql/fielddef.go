@@ -31,7 +31,7 @@ type FieldDef struct {
 	args argList
 
 	method  *reflect.Value
-	goField string
+	goIndex []int
 	mu      sync.Mutex
 }
 
@@ -78,14 +78,10 @@ func (f *FieldDef) getArg(name string) *Arg {
 	return f.args.get(name)
 }
 
-func (f *FieldDef) isDeprecated() bool {
-	return f.GetDirective(deprecatedStr) != nil
-}
-
 // Resolve returns one of the following:
 //   name: String!
 //   description: String
-//   args: [__InputValue!]!
+//   args(includeDeprecated: Boolean = false): [__InputValue!]!
 //   type: __Type!
 //   isDeprecated: Boolean!
 //   deprecationReason: String
@@ -96,7 +92,7 @@ func (f *FieldDef) Resolve(field *Field, args map[string]interface{}) (result in
 	case descriptionStr:
 		result = f.Desc
 	case argsStr:
-		result = &f.args
+		result = f.withoutDeprecatedArgs(args, &f.args)
 	case typeStr:
 		result = f.Type
 	case isDeprecatedStr:
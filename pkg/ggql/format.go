@@ -0,0 +1,600 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+const (
+	fmtOpKind = iota
+	fmtFragKind
+)
+
+const (
+	fmtFieldKind = iota
+	fmtSpreadKind
+	fmtInlineKind
+)
+
+// FormatOptions are the options for FormatExecutable().
+type FormatOptions struct {
+
+	// Indent is the number of spaces for each level of nesting. If zero
+	// then 2 spaces are used.
+	Indent int
+
+	// Sort operations, fragments, selections, arguments, variables, and
+	// object value fields by name instead of keeping the original order.
+	// Operations come before fragments and in a selection set fields come
+	// before fragment spreads which come before inline fragments.
+	Sort bool
+}
+
+// FormatExecutable returns a canonical form of an executable document such
+// as a query. Only the syntax is checked so the document does not need to be
+// valid against a schema. Comments are kept with the definition, selection,
+// or closing brace that follows them unless the comment is at the end of a
+// line with a selection in which case it stays at the end of the line.
+func FormatExecutable(src []byte, opts *FormatOptions) ([]byte, error) {
+	if opts == nil {
+		opts = &FormatOptions{}
+	}
+	p := &fmtParser{parser: parser{reader: bytes.NewReader(src)}, opts: opts}
+	p.comment = p.addComment
+	if err := p.skipBOM(); err != nil {
+		return nil, err
+	}
+	var defs []*fmtSel
+	for {
+		b, err := p.skipSpace()
+		if err != nil {
+			return nil, err
+		}
+		if b == 0 {
+			break
+		}
+		var def *fmtSel
+		if def, err = p.readDefinition(b); err != nil {
+			return nil, err
+		}
+		defs = append(defs, def)
+	}
+	indent := opts.Indent
+	if indent <= 0 {
+		indent = 2
+	}
+	var b bytes.Buffer
+	p.sortSels(defs)
+	for i, def := range defs {
+		if 0 < i {
+			_ = b.WriteByte('\n')
+		}
+		p.writeSel(&b, def, 0, indent)
+	}
+	if 0 < len(p.pending) {
+		if 0 < len(defs) {
+			_ = b.WriteByte('\n')
+		}
+		for _, c := range p.takePending() {
+			_, _ = b.WriteString(c)
+			_ = b.WriteByte('\n')
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// fmtSel is a definition or a selection. The text is everything before the
+// selection set already formatted.
+type fmtSel struct {
+	kind     int
+	key      string
+	text     string
+	comments []string
+	trailing string
+	hasSels  bool
+	sels     []*fmtSel
+	end      []string
+}
+
+type fmtComment struct {
+	line int
+	text string
+}
+
+// fmtParser reads an executable document with the basic parser but only
+// checks the syntax. Each definition or selection is formatted as it is read.
+type fmtParser struct {
+	parser
+	opts     *FormatOptions
+	pending  []fmtComment
+	lastSel  *fmtSel
+	prevLine int
+}
+
+// addComment is called for each comment skipped. A comment on the same line
+// as the end of the last selection becomes the trailing comment of that
+// selection. All others are kept until the next definition, selection, or
+// closing brace.
+func (p *fmtParser) addComment(line int, text string) {
+	if p.lastSel != nil && line == p.prevLine && len(p.lastSel.trailing) == 0 {
+		p.lastSel.trailing = text
+	} else {
+		p.pending = append(p.pending, fmtComment{line: line, text: text})
+	}
+}
+
+// consumed is called after each token is read with the line the token ended
+// on.
+func (p *fmtParser) consumed(line int) {
+	p.prevLine = line
+	p.lastSel = nil
+}
+
+func (p *fmtParser) takePending() (comments []string) {
+	for _, c := range p.pending {
+		comments = append(comments, c.text)
+	}
+	p.pending = nil
+	return
+}
+
+// finish is called when a definition or selection has been read. A comment
+// already read that is on the same line as the end of the selection becomes
+// the trailing comment of the selection.
+func (p *fmtParser) finish(sel *fmtSel) {
+	if 0 < len(p.pending) && p.pending[0].line == p.prevLine && len(sel.trailing) == 0 {
+		sel.trailing = p.pending[0].text
+		p.pending = p.pending[1:]
+	}
+	p.lastSel = sel
+}
+
+// unexpected returns an error for the byte on deck after a skipSpace().
+func (p *fmtParser) unexpected(expect string, b byte) error {
+	if b == 0 {
+		return parseError(p.line, p.col, "expected %s but found the end of document", expect)
+	}
+	return parseError(p.line, p.col-1, "expected %s but found '%c'", expect, b)
+}
+
+func (p *fmtParser) readPunct(c byte) error {
+	b, err := p.skipSpace()
+	if err == nil {
+		if b != c {
+			return p.unexpected("'"+string(c)+"'", b)
+		}
+		_, _ = p.readByte() // re-read c
+		p.consumed(p.line)
+	}
+	return err
+}
+
+func (p *fmtParser) readName() (string, error) {
+	b, err := p.skipSpace()
+	if err != nil {
+		return "", err
+	}
+	if charMap[b] != tokenChar {
+		return "", p.unexpected("a name", b)
+	}
+	line := p.line
+	var token string
+	if token, err = p.readToken(); err == nil {
+		p.consumed(line)
+	}
+	return token, err
+}
+
+func (p *fmtParser) readDefinition(b byte) (def *fmtSel, err error) {
+	def = &fmtSel{comments: p.takePending()}
+	if b != '{' { // not a query shorthand
+		var token string
+		line, col := p.line, p.col-1
+		if charMap[b] == tokenChar {
+			token, err = p.readName()
+		}
+		switch token {
+		case string(OpQuery), string(OpMutation), string(OpSubscription):
+			def.text = token
+			if b, err = p.skipSpace(); err == nil && charMap[b] == tokenChar {
+				if def.key, err = p.readName(); err == nil {
+					def.text += " " + def.key
+					b, err = p.skipSpace()
+				}
+			}
+			if err == nil && b == '(' {
+				var vars string
+				if vars, err = p.readVarDefs(); err == nil {
+					def.text += vars
+				}
+			}
+		case "fragment":
+			def.kind = fmtFragKind
+			var cond string
+			if def.key, err = p.readName(); err == nil {
+				_, err = p.skipSpace()
+				line, col = p.line, p.col-1
+			}
+			if err == nil {
+				if token, err = p.readName(); err == nil && token != "on" {
+					err = parseError(line, col, "missing fragment condition")
+				}
+			}
+			if err == nil {
+				cond, err = p.readName()
+			}
+			def.text = "fragment " + def.key + " on " + cond
+		case "":
+			err = p.unexpected("an operation or fragment", b)
+		default:
+			err = parseError(line, col, "expected an operation or fragment but found '%s'", token)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	var dirs string
+	if dirs, err = p.readDirectives(); err == nil {
+		def.text += dirs
+		err = p.readSelectionSet(def)
+	}
+	p.finish(def)
+
+	return
+}
+
+func (p *fmtParser) readSelectionSet(parent *fmtSel) error {
+	if err := p.readPunct('{'); err != nil {
+		return err
+	}
+	parent.hasSels = true
+	for {
+		b, err := p.skipSpace()
+		if err != nil {
+			return err
+		}
+		if b == '}' {
+			break
+		}
+		if b == 0 {
+			return p.unexpected("'}'", b)
+		}
+		var sel *fmtSel
+		if sel, err = p.readSelection(b); err != nil {
+			return err
+		}
+		parent.sels = append(parent.sels, sel)
+	}
+	if len(parent.sels) == 0 {
+		return parseError(p.line, p.col-1, "empty selection set")
+	}
+	parent.end = p.takePending()
+	_, _ = p.readByte() // re-read }
+	p.consumed(p.line)
+
+	return nil
+}
+
+func (p *fmtParser) readSelection(b byte) (sel *fmtSel, err error) {
+	sel = &fmtSel{comments: p.takePending()}
+	var s string
+	switch {
+	case b == '.':
+		for i := 0; i < 3 && err == nil; i++ {
+			if b, err = p.readByte(); err == nil && b != '.' {
+				err = parseError(p.line, p.col-1, "expected '%s'", spreadStr)
+			}
+		}
+		if err != nil {
+			return
+		}
+		p.consumed(p.line)
+		sel.kind = fmtInlineKind
+		sel.text = spreadStr
+		if b, err = p.skipSpace(); err == nil && charMap[b] == tokenChar {
+			if s, err = p.readName(); err == nil && s != "on" {
+				sel.kind = fmtSpreadKind
+				sel.key = s
+				sel.text += s
+				if s, err = p.readDirectives(); err == nil {
+					sel.text += s
+				}
+				break
+			}
+			if err == nil {
+				sel.key, err = p.readName()
+				sel.text += " on " + sel.key
+			}
+		}
+		if err == nil {
+			s, err = p.readDirectives()
+		}
+		if err == nil {
+			sel.text += s
+			err = p.readSelectionSet(sel)
+		}
+	case charMap[b] == tokenChar:
+		if sel.key, err = p.readName(); err != nil {
+			return
+		}
+		sel.text = sel.key
+		if b, err = p.skipSpace(); err == nil && b == ':' {
+			_, _ = p.readByte() // re-read :
+			if s, err = p.readName(); err == nil {
+				sel.text += ": " + s
+				b, err = p.skipSpace()
+			}
+		}
+		if err == nil && b == '(' {
+			if s, err = p.readArgs(); err == nil {
+				sel.text += s
+			}
+		}
+		if err == nil {
+			s, err = p.readDirectives()
+		}
+		if err == nil {
+			sel.text += s
+			if b, err = p.skipSpace(); err == nil && b == '{' {
+				err = p.readSelectionSet(sel)
+			}
+		}
+	default:
+		return nil, p.unexpected("a selection", b)
+	}
+	p.finish(sel)
+
+	return
+}
+
+// readMembers reads name and value pairs such as arguments or the fields of
+// an input object up to the close byte.
+func (p *fmtParser) readMembers(open, close byte) (string, error) {
+	if err := p.readPunct(open); err != nil {
+		return "", err
+	}
+	var members []string
+	for {
+		b, err := p.skipSpace()
+		if err != nil {
+			return "", err
+		}
+		if b == close {
+			break
+		}
+		var name, v string
+		if name, err = p.readName(); err == nil {
+			err = p.readPunct(':')
+		}
+		if err == nil {
+			v, err = p.readValue()
+		}
+		if err != nil {
+			return "", err
+		}
+		members = append(members, name+": "+v)
+	}
+	_, _ = p.readByte() // re-read close
+	p.consumed(p.line)
+	if p.opts.Sort {
+		sort.Strings(members)
+	}
+	return string(open) + strings.Join(members, ", ") + string(close), nil
+}
+
+func (p *fmtParser) readArgs() (string, error) {
+	return p.readMembers('(', ')')
+}
+
+func (p *fmtParser) readDirectives() (string, error) {
+	var sb strings.Builder
+	for {
+		b, err := p.skipSpace()
+		if err != nil {
+			return "", err
+		}
+		if b != '@' {
+			break
+		}
+		_, _ = p.readByte() // re-read @
+		var name string
+		if name, err = p.readName(); err != nil {
+			return "", err
+		}
+		sb.WriteString(" @")
+		sb.WriteString(name)
+		if b, err = p.skipSpace(); err == nil && b == '(' {
+			var args string
+			if args, err = p.readArgs(); err == nil {
+				sb.WriteString(args)
+			}
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return sb.String(), nil
+}
+
+func (p *fmtParser) readVarDefs() (string, error) {
+	_, _ = p.readByte() // re-read (
+	var vars []string
+	for {
+		b, err := p.skipSpace()
+		if err != nil {
+			return "", err
+		}
+		if b == ')' {
+			break
+		}
+		var name, typ, v, dirs string
+		if err = p.readPunct('$'); err == nil {
+			name, err = p.readName()
+		}
+		if err == nil {
+			err = p.readPunct(':')
+		}
+		if err == nil {
+			typ, err = p.readType()
+		}
+		if err == nil {
+			if b, err = p.skipSpace(); err == nil && b == '=' {
+				_, _ = p.readByte() // re-read =
+				if v, err = p.readValue(); err == nil {
+					typ += " = " + v
+				}
+			}
+		}
+		if err == nil {
+			dirs, err = p.readDirectives()
+		}
+		if err != nil {
+			return "", err
+		}
+		vars = append(vars, "$"+name+": "+typ+dirs)
+	}
+	_, _ = p.readByte() // re-read )
+	p.consumed(p.line)
+	if p.opts.Sort {
+		sort.Strings(vars)
+	}
+	return "(" + strings.Join(vars, ", ") + ")", nil
+}
+
+func (p *fmtParser) readType() (s string, err error) {
+	var b byte
+	if b, err = p.skipSpace(); err == nil && b == '[' {
+		_, _ = p.readByte() // re-read [
+		if s, err = p.readType(); err == nil {
+			err = p.readPunct(']')
+		}
+		s = "[" + s + "]"
+	} else if err == nil {
+		s, err = p.readName()
+	}
+	if err == nil {
+		if b, err = p.skipSpace(); err == nil && b == '!' {
+			_, _ = p.readByte() // re-read !
+			p.consumed(p.line)
+			s += "!"
+		}
+	}
+	return
+}
+
+func (p *fmtParser) readValue() (s string, err error) {
+	var b byte
+	if b, err = p.skipSpace(); err != nil {
+		return
+	}
+	line := p.line
+	switch {
+	case b == '"':
+		if s, err = p.readString(); err == nil {
+			p.consumed(p.line)
+			var buf bytes.Buffer
+			_ = writeString(&buf, s, true)
+			s = buf.String()
+		}
+	case b == '$':
+		_, _ = p.readByte() // re-read $
+		if s, err = p.readName(); err == nil {
+			s = "$" + s
+		}
+	case b == '-' || '0' <= b && b <= '9':
+		if s, err = p.readNumberToken(); err == nil {
+			p.consumed(line)
+		}
+	case b == '[':
+		_, _ = p.readByte() // re-read [
+		p.consumed(line)
+		var items []string
+		for {
+			if b, err = p.skipSpace(); err != nil {
+				return
+			}
+			if b == ']' {
+				break
+			}
+			if b == 0 {
+				return "", p.unexpected("']'", b)
+			}
+			var v string
+			if v, err = p.readValue(); err != nil {
+				return
+			}
+			items = append(items, v)
+		}
+		_, _ = p.readByte() // re-read ]
+		p.consumed(p.line)
+		s = "[" + strings.Join(items, ", ") + "]"
+	case b == '{':
+		s, err = p.readMembers('{', '}')
+	case charMap[b] == tokenChar:
+		s, err = p.readName()
+	default:
+		err = p.unexpected("a value", b)
+	}
+	return
+}
+
+func (p *fmtParser) sortSels(sels []*fmtSel) {
+	if !p.opts.Sort {
+		return
+	}
+	sort.SliceStable(sels, func(i, j int) bool {
+		if sels[i].kind != sels[j].kind {
+			return sels[i].kind < sels[j].kind
+		}
+		return sels[i].key < sels[j].key
+	})
+}
+
+func (p *fmtParser) writeSel(b *bytes.Buffer, sel *fmtSel, depth, indent int) {
+	in := strings.Repeat(" ", depth*indent)
+	for _, c := range sel.comments {
+		_, _ = b.WriteString(in)
+		_, _ = b.WriteString(c)
+		_ = b.WriteByte('\n')
+	}
+	_, _ = b.WriteString(in)
+	_, _ = b.WriteString(sel.text)
+	if sel.hasSels {
+		if 0 < len(sel.text) {
+			_ = b.WriteByte(' ')
+		}
+		_, _ = b.WriteString("{\n")
+		p.sortSels(sel.sels)
+		for _, s := range sel.sels {
+			p.writeSel(b, s, depth+1, indent)
+		}
+		for _, c := range sel.end {
+			_, _ = b.WriteString(in)
+			_, _ = b.WriteString(strings.Repeat(" ", indent))
+			_, _ = b.WriteString(c)
+			_ = b.WriteByte('\n')
+		}
+		_, _ = b.WriteString(in)
+		_ = b.WriteByte('}')
+	}
+	if 0 < len(sel.trailing) {
+		_ = b.WriteByte(' ')
+		_, _ = b.WriteString(sel.trailing)
+	}
+	_ = b.WriteByte('\n')
+}
@@ -0,0 +1,138 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql_test
+
+import (
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+const formatSrc = `# leading
+query   Q($b:Int=3,$a : [String!]! @dir)@x{
+  # before user
+  user(id:1,name:"x\"y",obj:{z:1,a:[1,2,3]}) @skip(if:$a) { name # trailing
+  ...frag ... on User{ id }
+  alias : zed
+  # end of user
+  }
+}
+{ shorthand }
+fragment frag on User { b a }
+# final
+`
+
+func TestFormatExecutable(t *testing.T) {
+	out, err := ggql.FormatExecutable([]byte(formatSrc), nil)
+	checkNil(t, err, "FormatExecutable failed. %s", err)
+	checkEqual(t, `# leading
+query Q($b: Int = 3, $a: [String!]! @dir) @x {
+  # before user
+  user(id: 1, name: "x\"y", obj: {z: 1, a: [1, 2, 3]}) @skip(if: $a) {
+    name # trailing
+    ...frag
+    ... on User {
+      id
+    }
+    alias: zed
+    # end of user
+  }
+}
+
+{
+  shorthand
+}
+
+fragment frag on User {
+  b
+  a
+}
+
+# final
+`, string(out), "format mismatch")
+
+	again, err := ggql.FormatExecutable(out, nil)
+	checkNil(t, err, "FormatExecutable failed. %s", err)
+	checkEqual(t, string(out), string(again), "format of formatted output should not change")
+}
+
+func TestFormatExecutableSort(t *testing.T) {
+	out, err := ggql.FormatExecutable([]byte(formatSrc), &ggql.FormatOptions{Indent: 4, Sort: true})
+	checkNil(t, err, "FormatExecutable failed. %s", err)
+	checkEqual(t, `{
+    shorthand
+}
+
+# leading
+query Q($a: [String!]! @dir, $b: Int = 3) @x {
+    # before user
+    user(id: 1, name: "x\"y", obj: {a: [1, 2, 3], z: 1}) @skip(if: $a) {
+        alias: zed
+        name # trailing
+        ...frag
+        ... on User {
+            id
+        }
+        # end of user
+    }
+}
+
+fragment frag on User {
+    a
+    b
+}
+
+# final
+`, string(out), "format mismatch")
+}
+
+func TestFormatExecutableValues(t *testing.T) {
+	for _, d := range []struct {
+		src    string
+		expect string
+	}{
+		{src: `{ a(x: EMPIRE) }`, expect: "{\n  a(x: EMPIRE)\n}\n"},
+		{src: `{ a(x: [EMPIRE]) }`, expect: "{\n  a(x: [EMPIRE])\n}\n"},
+		{src: `{ a(x: [1, ENUM]) }`, expect: "{\n  a(x: [1, ENUM])\n}\n"},
+		{src: `{ a(x: {e: ENUM, x: Echo}) }`, expect: "{\n  a(x: {e: ENUM, x: Echo})\n}\n"},
+		{src: `{ a(x: -1.5e3, y: 2E-2) }`, expect: "{\n  a(x: -1.5e3, y: 2E-2)\n}\n"},
+	} {
+		out, err := ggql.FormatExecutable([]byte(d.src), nil)
+		checkNil(t, err, "FormatExecutable(%s) failed. %s", d.src, err)
+		checkEqual(t, d.expect, string(out), "format mismatch for %s", d.src)
+	}
+}
+
+func TestFormatExecutableError(t *testing.T) {
+	for _, d := range []struct {
+		src    string
+		expect string
+	}{
+		{src: `{ a `, expect: "parse error: expected '}' but found the end of document from 1:5"},
+		{src: `{ }`, expect: "parse error: empty selection set from 1:3"},
+		{src: `type Query { a: Int }`, expect: "parse error: expected an operation or fragment but found 'type' from 1:1"},
+		{src: `{ a(x: "abc) }`, expect: "parse error: string not terminated from 1:15"},
+		{src: `{ a(x: """abc) }`, expect: "parse error: string not terminated from 1:17"},
+		{src: `fragment f User { a }`, expect: "parse error: missing fragment condition from 1:12"},
+		{src: `{ a(x: ) }`, expect: "parse error: expected a value but found ')' from 1:8"},
+		{src: `{ a(x: [1 `, expect: "parse error: expected ']' but found the end of document from 1:11"},
+		{src: `{ a ^ }`, expect: "parse error: expected a selection but found '^' from 1:5"},
+		{src: `{ a.b }`, expect: "parse error: expected '...' from 1:5"},
+	} {
+		_, err := ggql.FormatExecutable([]byte(d.src), nil)
+		checkNotNil(t, err, "FormatExecutable(%s) should fail", d.src)
+		checkEqual(t, d.expect, err.Error(), "error mismatch for %s", d.src)
+	}
+}
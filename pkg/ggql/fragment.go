@@ -38,9 +38,7 @@ func (f *Fragment) String() string {
 // Validate a type.
 func (f *Fragment) Validate(root *Root) (errs []error) {
 	errs = append(errs, f.SelBase.Validate(root)...)
-	for _, du := range f.Directives() {
-		errs = append(errs, root.validateDirUse(f.Name, Locate(f), du)...)
-	}
+	errs = append(errs, root.validateDirUseList(f.Name, Locate(f), f.Directives())...)
 	// Additional argument checks are performed during the resolve phase so no
 	// need to attempt to validate argument type matching and coerce success.
 	return
@@ -53,9 +53,7 @@ func (fr *FragRef) SelectionSet() []Selection {
 
 // Validate a type.
 func (fr *FragRef) Validate(root *Root) (errs []error) {
-	for _, du := range fr.Directives() {
-		errs = append(errs, root.validateDirUse(fr.Fragment.Name, Locate(fr), du)...)
-	}
+	errs = append(errs, root.validateDirUseList(fr.Fragment.Name, Locate(fr), fr.Directives())...)
 	return
 }
 
@@ -22,3 +22,20 @@ type InCoercer interface {
 	// otherwise an error is returned.
 	CoerceIn(v interface{}) (interface{}, error)
 }
+
+// coerceIn coerces v with co using the relaxed flag in place of the package
+// Relaxed variable for enums including enums in lists, non-null types, and
+// input objects.
+func coerceIn(co InCoercer, v interface{}, relaxed bool) (interface{}, error) {
+	switch tc := co.(type) {
+	case *Enum:
+		return tc.coerceIn(v, relaxed)
+	case *List:
+		return tc.coerceIn(v, relaxed)
+	case *NonNull:
+		return tc.coerceIn(v, relaxed)
+	case *Input:
+		return tc.coerceIn(v, relaxed)
+	}
+	return co.CoerceIn(v)
+}
@@ -39,9 +39,7 @@ func (in *Inline) String() string {
 // Validate a type.
 func (in *Inline) Validate(root *Root) (errs []error) {
 	errs = append(errs, in.SelBase.Validate(root)...)
-	for _, du := range in.Directives() {
-		errs = append(errs, root.validateDirUse("...", Locate(in), du)...)
-	}
+	errs = append(errs, root.validateDirUseList("...", Locate(in), in.Directives())...)
 	// Additional argument checks are performed during the resolve phase so no
 	// need to attempt to validate argument type matching and coerce success.
 	return
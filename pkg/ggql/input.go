@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"io"
 	"reflect"
-	"strings"
 )
 
 // Input is a GraphQL InputObject type.
@@ -31,6 +30,7 @@ type Input struct {
 	fields inputFieldList
 
 	meta reflect.Type
+	root *Root
 }
 
 // Rank of the type.
@@ -91,7 +91,13 @@ func (t *Input) Extend(x Type) error {
 
 // CoerceIn coerces an input value into the expected input type if possible
 // otherwise an error is returned.
+// The package Relaxed variable determines if the relaxed coercion rules
+// are used. Requests use the Relaxed option of the Root instead.
 func (t *Input) CoerceIn(v interface{}) (interface{}, error) {
+	return t.coerceIn(v, Relaxed)
+}
+
+func (t *Input) coerceIn(v interface{}, relaxed bool) (interface{}, error) {
 	switch tv := v.(type) {
 	case nil:
 		// nil is okay at this point
@@ -101,6 +107,11 @@ func (t *Input) CoerceIn(v interface{}) (interface{}, error) {
 				return nil, fmt.Errorf("%s is not a field in %s", k, t.Name())
 			}
 		}
+		if t.GetDirective(oneOfStr) != nil {
+			if err := t.checkOneOf(tv); err != nil {
+				return nil, err
+			}
+		}
 		var rv reflect.Value
 		rt := t.meta
 		if rt != nil {
@@ -126,7 +137,7 @@ func (t *Input) CoerceIn(v interface{}) (interface{}, error) {
 					return nil, fmt.Errorf("%s is required but missing", k)
 				}
 			} else if co, _ := f.Type.(InCoercer); co != nil {
-				if cv, err := co.CoerceIn(ov); err == nil {
+				if cv, err := coerceIn(co, ov, relaxed); err == nil {
 					if rt != nil {
 						if err = t.reflectSetKey(rv, k, cv); err != nil {
 							return nil, inErr(err, k)
@@ -152,7 +163,21 @@ func (t *Input) CoerceIn(v interface{}) (interface{}, error) {
 	return v, nil
 }
 
-func inErr(err error, k string) error {
+// checkOneOf verifies exactly one field of a @oneOf input object is set and
+// that it is not null.
+func (t *Input) checkOneOf(m map[string]interface{}) error {
+	if len(m) != 1 {
+		return fmt.Errorf("%w, exactly one field of %s must be set, not %d", ErrCoerce, t.N, len(m))
+	}
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("%w, field %s of %s must not be null", ErrCoerce, k, t.N)
+		}
+	}
+	return nil
+}
+
+func inErr(err error, k interface{}) error {
 	var gerr *Error
 	if errors.As(err, &gerr) {
 		gerr.in(k)
@@ -165,59 +190,12 @@ func inErr(err error, k string) error {
 
 func (t *Input) reflectSetKey(rv reflect.Value, key string, v interface{}) (err error) {
 	rv = rv.Elem()
-	rv = rv.FieldByNameFunc(func(k string) bool { return strings.EqualFold(k, key) })
-
-	return t.reflectSet(rv, v)
-}
-
-func (t *Input) reflectSet(rv reflect.Value, v interface{}) (err error) {
-	if rv.CanSet() {
-		vv := reflect.ValueOf(v)
-		vt := vv.Type()
-		if vt.AssignableTo(rv.Type()) {
-			rv.Set(vv)
-			return
-		}
-		// Try type conversions of int and float types.
-		switch rv.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			switch vt.Kind() {
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				rv.SetInt(vv.Int())
-				return
-			}
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			switch vt.Kind() {
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				if i := vv.Int(); 0 <= i {
-					rv.SetUint(uint64(i))
-					return
-				}
-			}
-		case reflect.Float32, reflect.Float64:
-			switch vt.Kind() {
-			case reflect.Float32, reflect.Float64:
-				rv.SetFloat(vv.Float())
-				return
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				rv.SetFloat(float64(vv.Int()))
-				return
-			}
-		case reflect.Slice:
-			if vt.Kind() == reflect.Slice {
-				i := vv.Len()
-				av := reflect.MakeSlice(rv.Type(), i, i)
-				for i--; 0 <= i; i-- {
-					if err = t.reflectSet(av.Index(i), vv.Index(i).Interface()); err != nil {
-						return
-					}
-				}
-				rv.Set(av)
-				return
-			}
-		}
+	withJSON := t.root != nil && t.root.JSONTags
+	sf, ok := findGoField(rv.Type(), key, withJSON)
+	if !ok {
+		return fmt.Errorf("%w: %s is not a field of %s", ErrMeta, key, rv.Type())
 	}
-	return fmt.Errorf("can not coerce a %T into a %s", v, rv.Kind())
+	return decodeValue(fieldByIndex(rv, sf.Index), v, withJSON)
 }
 
 // Validate a type.
@@ -229,6 +207,16 @@ func (t *Input) Validate(root *Root) (errs []error) {
 				errs = append(errs, fmt.Errorf("%w, %s does not return an input type at %d:%d",
 					ErrValidation, f.Name(), f.line, f.col))
 			}
+			if _, ok := f.Type.(*NonNull); ok && f.Default == nil && f.isDeprecated() {
+				errs = append(errs, fmt.Errorf("%w, required input field %s can not be deprecated at %d:%d",
+					ErrValidation, f.Name(), f.line, f.col))
+			}
+			if t.GetDirective(oneOfStr) != nil {
+				if _, ok := f.Type.(*NonNull); ok || f.Default != nil {
+					errs = append(errs, fmt.Errorf("%w, @oneOf field %s must be nullable without a default at %d:%d",
+						ErrValidation, f.Name(), f.line, f.col))
+				}
+			}
 		}
 	} else {
 		errs = append(errs, fmt.Errorf("%w, input object %s must have at least one field at %d:%d",
@@ -245,8 +233,9 @@ func (t *Input) Validate(root *Root) (errs []error) {
 //   interfaces: [__Type!]
 //   possibleTypes: [__Type!]
 //   enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
-//   inputfields: [__InputValue!]
+//   inputFields(includeDeprecated: Boolean = false): [__InputValue!]
 //   ofType: __Type
+//   specifiedByURL: String
 func (t *Input) Resolve(field *Field, args map[string]interface{}) (result interface{}, err error) {
 	switch field.Name {
 	case kindStr:
@@ -256,8 +245,18 @@ func (t *Input) Resolve(field *Field, args map[string]interface{}) (result inter
 	case descriptionStr:
 		result = t.Desc
 	case inputFieldsStr:
-		result = &t.fields
-	case possibleTypesStr, interfacesStr, enumValuesStr, fieldsStr, ofTypeStr:
+		if t.getBoolArg(args, includeDeprecatedStr) {
+			result = &t.fields
+		} else {
+			list := inputFieldList{dict: map[string]*InputField{}}
+			for _, f := range t.fields.list {
+				if !f.isDeprecated() {
+					_ = list.add(f)
+				}
+			}
+			result = &list
+		}
+	case possibleTypesStr, interfacesStr, enumValuesStr, fieldsStr, ofTypeStr, specifiedByURLStr:
 		// return nil
 	}
 	return
@@ -16,6 +16,8 @@ package ggql_test
 
 import (
 	"bytes"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/uhn/ggql/pkg/ggql"
@@ -113,3 +115,54 @@ func TestInputFields(t *testing.T) {
 	input := ggql.Input{}
 	checkEqual(t, 0, len(input.Fields()), "Input Fields should be empty")
 }
+
+const oneOfSdl = `
+type Query { find(by: FindBy): String }
+input FindBy @oneOf {
+  id: ID
+  name: String
+}
+`
+
+type oneOfQuery struct {
+}
+
+func (q *oneOfQuery) Find(by map[string]interface{}) string {
+	return fmt.Sprint(by)
+}
+
+func TestInputOneOf(t *testing.T) {
+	ggql.Sort = true
+	root := ggql.NewRoot(&struct{ Query *oneOfQuery }{Query: &oneOfQuery{}})
+	err := root.ParseString(oneOfSdl)
+	checkNil(t, err, "no error should be returned when parsing a valid SDL. %s", err)
+
+	input, _ := root.GetType("FindBy").(*ggql.Input)
+	checkNotNil(t, input, "FindBy should be an Input")
+
+	v, err := input.CoerceIn(map[string]interface{}{"name": "Fazerdaze"})
+	checkNil(t, err, "CoerceIn with one field should not fail. %s", err)
+	checkEqual(t, "Fazerdaze", v.(map[string]interface{})["name"], "name should be set")
+
+	for _, bad := range []map[string]interface{}{
+		{},
+		{"id": "1", "name": "Fazerdaze"},
+		{"name": nil},
+	} {
+		_, err = input.CoerceIn(bad)
+		checkNotNil(t, err, "CoerceIn of %v should fail", bad)
+	}
+	result := root.ResolveString(`{find(by: {id: "1", name: "x"})}`, "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, true, strings.Contains(b.String(), "exactly one field of FindBy must be set"), "result. %s", b.String())
+
+	err = ggql.NewRoot(nil).ParseString(`
+type Query { find(by: FindBy): String }
+input FindBy @oneOf {
+  id: ID!
+  name: String = "x"
+}
+`)
+	checkNotNil(t, err, "@oneOf fields must be nullable without defaults")
+}
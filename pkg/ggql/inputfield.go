@@ -66,6 +66,8 @@ func (f *InputField) Write(w io.Writer, desc bool) (err error) {
 //   description: String
 //   type: __Type!
 //   defaultValue: String
+//   isDeprecated: Boolean!
+//   deprecationReason: String
 func (f *InputField) Resolve(field *Field, args map[string]interface{}) (result interface{}, err error) {
 	switch field.Name {
 	case nameStr:
@@ -76,6 +78,10 @@ func (f *InputField) Resolve(field *Field, args map[string]interface{}) (result
 		result = f.Type
 	case defaultValueStr:
 		result = f.Default
+	case isDeprecatedStr:
+		result = f.isDeprecated()
+	case deprecationReasonStr:
+		result = f.deprecationReason()
 	}
 	return
 }
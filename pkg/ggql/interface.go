@@ -26,6 +26,9 @@ type Interface struct {
 
 	Root *Root // needed to get possibleTypes
 
+	// Interfaces the interface implements.
+	Interfaces []Type
+
 	// Fields in the interface.
 	fields fieldList
 }
@@ -56,7 +59,7 @@ func (t *Interface) SDL(desc ...bool) string {
 
 // Write the type as SDL.
 func (t *Interface) Write(w io.Writer, desc bool) (err error) {
-	if err = t.writeHeader(w, "interface ", desc); err == nil {
+	if err = t.writeHeader(w, "interface ", desc, t.Interfaces...); err == nil {
 		for _, fd := range t.fields.list {
 			if err = fd.Write(w, desc); err != nil {
 				break
@@ -77,6 +80,12 @@ func (t *Interface) Extend(x Type) error {
 				return fmt.Errorf("%w: on %s", err, t.N)
 			}
 		}
+		for _, i := range ix.Interfaces {
+			if hasType(t.Interfaces, i) {
+				return fmt.Errorf("%w: interface %s already exists on %s", ErrDuplicate, i.Name(), t.N)
+			}
+			t.Interfaces = append(t.Interfaces, i)
+		}
 	}
 	return t.Base.Extend(x)
 }
@@ -88,9 +97,43 @@ func (t *Interface) GetField(name string) *FieldDef {
 
 // Validate a type.
 func (t *Interface) Validate(root *Root) (errs []error) {
+	if t.isImplementedBy(t) {
+		errs = append(errs, fmt.Errorf("%w, interface %s implements itself at %d:%d",
+			ErrValidation, t.Name(), t.line, t.col))
+	} else {
+		errs = append(errs, t.validateImplements(&t.fields, t.Interfaces)...)
+	}
 	return append(errs, t.validateFieldDefs(t.Name(), &t.fields)...)
 }
 
+// isImplementedBy returns true if x is an Object or Interface that
+// implements the interface directly or through another interface.
+func (t *Interface) isImplementedBy(x Type) bool {
+	return t.implementedBy(x, map[Type]bool{})
+}
+
+func (t *Interface) implementedBy(x Type, seen map[Type]bool) bool {
+	var list []Type
+	switch tx := x.(type) {
+	case *Object:
+		list = tx.Interfaces
+	case *Interface:
+		list = tx.Interfaces
+	}
+	for _, i := range list {
+		if typeEqual(i, t) {
+			return true
+		}
+		if !seen[i] {
+			seen[i] = true
+			if t.implementedBy(i, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // AddField is used to add fields to an interface.
 func (t *Interface) AddField(fd *FieldDef) error {
 	return t.fields.add(fd)
@@ -106,6 +149,7 @@ func (t *Interface) AddField(fd *FieldDef) error {
 //   enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
 //   inputfields: [__InputValue!]
 //   ofType: __Type
+//   specifiedByURL: String
 func (t *Interface) Resolve(field *Field, args map[string]interface{}) (result interface{}, err error) {
 	switch field.Name {
 	case kindStr:
@@ -118,7 +162,9 @@ func (t *Interface) Resolve(field *Field, args map[string]interface{}) (result i
 		result = &t.fields
 	case possibleTypesStr:
 		result = t.possibleTypes()
-	case interfacesStr, enumValuesStr, inputFieldsStr, ofTypeStr:
+	case interfacesStr:
+		result = t.Interfaces
+	case enumValuesStr, inputFieldsStr, ofTypeStr, specifiedByURLStr:
 		// nil result
 	}
 	return
@@ -128,12 +174,8 @@ func (t *Interface) possibleTypes() *typeList {
 	list := newTypeList()
 
 	for _, pt := range t.Root.types.list {
-		if obj, _ := pt.(*Object); obj != nil {
-			for _, i := range obj.Interfaces {
-				if t == i {
-					list.add(obj)
-				}
-			}
+		if obj, _ := pt.(*Object); obj != nil && t.isImplementedBy(obj) {
+			list.add(obj)
 		}
 	}
 	return list
@@ -15,6 +15,7 @@
 package ggql_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/uhn/ggql/pkg/ggql"
@@ -116,3 +117,155 @@ interface Inty {
 
 	checkNotNil(t, err, "duplicate field for interface.Extend should have failed.")
 }
+
+type implPerson struct {
+	ID   string
+	Name string
+}
+
+type implQuery struct{}
+
+func (q *implQuery) Node() interface{} {
+	return &implPerson{ID: "p1", Name: "Pat"}
+}
+
+type implSchema struct {
+	Query *implQuery
+}
+
+func TestInterfaceImplements(t *testing.T) {
+	root := ggql.NewRoot(&implSchema{Query: &implQuery{}})
+	err := root.ParseString(`
+type Query {
+  node: Node
+}
+interface Entity {
+  id: String!
+}
+interface Node implements Entity {
+  id: String!
+  name: String
+}
+type Person implements Node & Entity {
+  id: String!
+  name: String
+}
+`)
+	checkNil(t, err, "parse failed. %s", err)
+	err = root.RegisterType(&implPerson{}, "Person")
+	checkNil(t, err, "register failed. %s", err)
+
+	checkEqual(t, `interface Node implements Entity {
+  id: String!
+  name: String
+}
+`, root.GetType("Node").String(), "Interface String() mismatch")
+
+	result := root.ResolveString(`{
+  __type(name: "Node") {
+    interfaces { name }
+  }
+  node {
+    __typename
+    ... on Entity { id }
+    ... on Person { name }
+  }
+}`, "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, 2)
+	checkEqual(t, `{
+  "data": {
+    "__type": {
+      "interfaces": [
+        {
+          "name": "Entity"
+        }
+      ]
+    },
+    "node": {
+      "__typename": "Person",
+      "id": "p1",
+      "name": "Pat"
+    }
+  }
+}
+`, b.String(), "result mismatch")
+
+	result = root.ResolveString(`{__type(name: "Entity") {possibleTypes { name }}}`, "", nil)
+	b.Reset()
+	_ = ggql.WriteJSONValue(&b, result, 2)
+	checkEqual(t, `{
+  "data": {
+    "__type": {
+      "possibleTypes": [
+        {
+          "name": "Person"
+        }
+      ]
+    }
+  }
+}
+`, b.String(), "result mismatch")
+}
+
+func TestInterfaceUnregistered(t *testing.T) {
+	root := ggql.NewRoot(&implSchema{Query: &implQuery{}})
+	err := root.ParseString(`
+type Query {
+  node: Node
+}
+interface Node {
+  id: String!
+}
+type Person implements Node @go(type: "implPerson") {
+  id: String!
+  name: String
+}
+`)
+	checkNil(t, err, "parse failed. %s", err)
+
+	result := root.ResolveString(`{node {__typename id ... on Person { name }}}`, "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, 2)
+	checkEqual(t, `{
+  "data": {
+    "node": {
+      "__typename": "Person",
+      "id": "p1",
+      "name": "Pat"
+    }
+  }
+}
+`, b.String(), "result mismatch")
+
+	// Once from the cache of found types and once after a schema change.
+	for _, extra := range []string{"type ExtraA { x: Int }", "type ExtraB { x: Int }"} {
+		result = root.ResolveString(`{node {__typename id}}`, "", nil)
+		b.Reset()
+		_ = ggql.WriteJSONValue(&b, result, -1)
+		checkEqual(t, `{"data":{"node":{"__typename":"Person","id":"p1"}}}`, b.String(), "result mismatch before %s", extra)
+		err = root.ParseString(extra)
+		checkNil(t, err, "parse failed. %s", err)
+	}
+}
+
+func TestInterfaceImplementsError(t *testing.T) {
+	for _, sdl := range []string{
+		`interface Entity { id: String! }
+interface Node implements Entity { name: String }
+type Query { node: Node }`,
+		`interface Entity { id: String! }
+interface Node implements Entity { id: String! }
+type Person implements Node { id: String! }
+type Query { node: Node }`,
+		`interface Entity implements Node { id: String! }
+interface Node implements Entity { id: String! }
+type Query { node: Node }`,
+		`interface Node implements Query { id: String! }
+type Query { node: Node }`,
+	} {
+		root := ggql.NewRoot(nil)
+		err := root.ParseString(sdl)
+		checkNotNil(t, err, "parse of %s should fail", sdl)
+	}
+}
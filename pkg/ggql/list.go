@@ -92,7 +92,13 @@ func (t *List) Validate(root *Root) (errs []error) {
 
 // CoerceIn coerces an input value into the expected input type if possible
 // otherwise an error is returned.
+// The package Relaxed variable determines if the relaxed coercion rules
+// are used. Requests use the Relaxed option of the Root instead.
 func (t *List) CoerceIn(v interface{}) (interface{}, error) {
+	return t.coerceIn(v, Relaxed)
+}
+
+func (t *List) coerceIn(v interface{}, relaxed bool) (interface{}, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -101,7 +107,7 @@ func (t *List) CoerceIn(v interface{}) (interface{}, error) {
 			var cv interface{}
 			var err error
 			for i := len(list) - 1; 0 <= i; i-- {
-				cv, err = co.CoerceIn(list[i])
+				cv, err = coerceIn(co, list[i], relaxed)
 				if err == nil {
 					list[i] = cv
 				} else {
@@ -154,6 +160,7 @@ func (t *List) CoerceOut(v interface{}) (interface{}, error) {
 //   enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
 //   inputfields: [__InputValue!]
 //   ofType: __Type
+//   specifiedByURL: String
 func (t *List) Resolve(field *Field, args map[string]interface{}) (interface{}, error) {
 	switch field.Name {
 	case kindStr, descriptionStr:
@@ -162,7 +169,7 @@ func (t *List) Resolve(field *Field, args map[string]interface{}) (interface{},
 		return t.Name(), nil
 	case ofTypeStr:
 		return t.Base, nil
-	case interfacesStr, fieldsStr, possibleTypesStr, enumValuesStr, inputFieldsStr:
+	case interfacesStr, fieldsStr, possibleTypesStr, enumValuesStr, inputFieldsStr, specifiedByURLStr:
 		return nil, nil
 	}
 	return nil, fmt.Errorf("type __Type does not have field %s", field)
@@ -148,6 +148,11 @@ func Locate(t interface{}) Location {
 		return LocFragmentSpread
 	case *VarDef:
 		return LocVariableDefinition
+	case Type:
+		// Custom scalars that embed a Scalar.
+		if tt.Rank() == rankScalar {
+			return LocScalar
+		}
 	}
 	return ""
 }
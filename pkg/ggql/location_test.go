@@ -20,6 +20,10 @@ import (
 	"github.com/uhn/ggql/pkg/ggql"
 )
 
+type customScalar struct {
+	ggql.Scalar
+}
+
 func TestLocate(t *testing.T) {
 	for loc, v := range map[ggql.Location]interface{}{
 		ggql.LocObject:               &ggql.Object{},
@@ -38,6 +42,7 @@ func TestLocate(t *testing.T) {
 		checkEqual(t, string(loc), string(ggql.Locate(v)),
 			"locate %T should return %s not '%s'", v, loc, ggql.Locate(v))
 	}
+	checkEqual(t, string(ggql.LocScalar), string(ggql.Locate(&customScalar{})), "locate a custom scalar")
 }
 
 func TestIsInputType(t *testing.T) {
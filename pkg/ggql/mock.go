@@ -0,0 +1,283 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+const defaultMockListLen = 3
+
+var mockWords = []string{
+	"alpha", "bravo", "cedar", "delta", "ember", "falcon", "garnet", "harbor",
+	"indigo", "juniper", "kestrel", "lumen", "maple", "nova", "onyx", "pepper",
+	"quartz", "river", "sierra", "tango", "umber", "velvet", "willow", "zephyr",
+}
+
+// MockOptions are the options for a root created with NewMockRoot().
+type MockOptions struct {
+
+	// Seed for the generated values. The same seed, schema, and request
+	// always produce the same result.
+	Seed int64
+
+	// ListLen is the number of elements in generated lists. If zero then
+	// lists have 3 elements.
+	ListLen int
+
+	// Fixtures are values to use instead of generated values keyed by the
+	// type name. For an object type the fixture is a map of field names to
+	// values and fields not in the map are generated. The value of a field
+	// with an object, interface, or union type can be a map as well. A map
+	// for an interface or union can include a __typename to pick the object
+	// type. For scalar and enum types the fixture is the value to use. A
+	// fixture can also be a list in which case one of the elements is
+	// picked for each value.
+	Fixtures map[string]interface{}
+}
+
+// NewMockRoot returns a root that resolves any request against the schema
+// with generated values. The schema should be added with one of the parse
+// functions. Values are generated for each scalar type based on the seed
+// and the path to the value so the same request returns the same result.
+// Objects are resolved with an AnyResolver so most other root settings
+// apply as usual. Subscriptions are not supported.
+func NewMockRoot(opts *MockOptions, exclude ...string) *Root {
+	if opts == nil {
+		opts = &MockOptions{}
+	}
+	root := NewRoot(&mockValue{}, exclude...)
+	root.AnyResolver = &mockResolver{root: root, opts: opts}
+
+	return root
+}
+
+// typedValue is implemented by values that know their GraphQL object type
+// such as the values generated by a mock root.
+type typedValue interface {
+	objectType() *Object
+}
+
+// mockValue is a generated object value. An obj of nil is the schema.
+type mockValue struct {
+	obj  *Object
+	path string
+	fix  map[string]interface{}
+}
+
+func (v *mockValue) objectType() *Object {
+	return v.obj
+}
+
+type mockResolver struct {
+	root *Root
+	opts *MockOptions
+}
+
+// Resolve a field on a generated object.
+func (mr *mockResolver) Resolve(obj interface{}, field *Field, args map[string]interface{}) (interface{}, error) {
+	mv, _ := obj.(*mockValue)
+	if mv == nil {
+		return nil, fmt.Errorf("%w, expected a mock value, not a %T", ErrResolve, obj)
+	}
+	var fd *FieldDef
+	if mv.obj == nil {
+		fd = mr.root.schema.GetField(field.Name)
+	} else {
+		fd = mv.obj.GetField(field.Name)
+	}
+	if fd == nil {
+		return nil, fmt.Errorf("%w, %s is not a field", ErrResolve, field.Name)
+	}
+	path := mv.path + "." + field.Name
+	if 0 < len(args) {
+		path += fmt.Sprintf("%v", args)
+	}
+	if fv, has := mv.fix[field.Name]; has {
+		return mr.fixture(fv, fd.Type, path), nil
+	}
+	return mr.generate(fd.Type, path), nil
+}
+
+// Len of a list. Generated lists are []interface{} so this is only called
+// for other values.
+func (mr *mockResolver) Len(list interface{}) int {
+	return 0
+}
+
+// Nth element of a list. Generated lists are []interface{} so this is only
+// called for other values.
+func (mr *mockResolver) Nth(list interface{}, i int) (interface{}, error) {
+	return nil, fmt.Errorf("%w, expected a list, not a %T", ErrResolve, list)
+}
+
+func (mr *mockResolver) hash(path string) uint64 {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%d%s", mr.opts.Seed, path)
+	return h.Sum64()
+}
+
+func (mr *mockResolver) generate(t Type, path string) interface{} {
+	switch tt := t.(type) {
+	case *NonNull:
+		return mr.generate(tt.Base, path)
+	case *List:
+		n := mr.opts.ListLen
+		if n <= 0 {
+			n = defaultMockListLen
+		}
+		list := make([]interface{}, n)
+		for i := range list {
+			list[i] = mr.generate(tt.Base, fmt.Sprintf("%s[%d]", path, i))
+		}
+		return list
+	}
+	h := mr.hash(path)
+	if fix, has := mr.opts.Fixtures[t.Name()]; has {
+		if list, ok := fix.([]interface{}); ok && 0 < len(list) {
+			fix = list[h%uint64(len(list))]
+		}
+		return mr.fixture(fix, t, path)
+	}
+	switch tt := t.(type) {
+	case *Object:
+		return &mockValue{obj: tt, path: path}
+	case *Interface, *Union:
+		objs := mr.objects(t)
+		if len(objs) == 0 {
+			return nil
+		}
+		return mr.generate(objs[h%uint64(len(objs))], path)
+	case *Enum:
+		if len(tt.values.list) == 0 {
+			return nil
+		}
+		return string(tt.values.list[h%uint64(len(tt.values.list))].Value)
+	case *stringScalar:
+		if tt.core {
+			return mockWord(h)
+		}
+	case *idScalar:
+		return fmt.Sprintf("%08x", uint32(h))
+	case *intScalar:
+		return int32(h % 1000)
+	case *int64Scalar:
+		return int64(h >> 1)
+	case *floatScalar, *float64Scalar:
+		return float64(h%100000) / 100.0
+	case *booleanScalar:
+		return h&1 == 1
+	case *timeScalar:
+		return mockTime(h)
+	}
+	return mockScalar(t, h)
+}
+
+// fixture converts a fixture value to a value for type t. Maps for object,
+// interface, and union types become mock values that use the map for field
+// values.
+func (mr *mockResolver) fixture(v interface{}, t Type, path string) interface{} {
+	switch tt := t.(type) {
+	case *NonNull:
+		return mr.fixture(v, tt.Base, path)
+	case *List:
+		if list, ok := v.([]interface{}); ok {
+			out := make([]interface{}, len(list))
+			for i, x := range list {
+				out[i] = mr.fixture(x, tt.Base, fmt.Sprintf("%s[%d]", path, i))
+			}
+			return out
+		}
+	case *Object, *Interface, *Union:
+		if m, ok := v.(map[string]interface{}); ok {
+			mv := &mockValue{path: path, fix: m}
+			if name, _ := m["__typename"].(string); 0 < len(name) {
+				mv.obj, _ = mr.root.GetType(name).(*Object)
+			}
+			if mv.obj == nil {
+				if objs := mr.objects(t); 0 < len(objs) {
+					mv.obj = objs[mr.hash(path)%uint64(len(objs))]
+				}
+			}
+			if mv.obj == nil {
+				return nil
+			}
+			return mv
+		}
+	}
+	return v
+}
+
+// objects returns the object types that can be used for an object,
+// interface, or union type.
+func (mr *mockResolver) objects(t Type) (objs []*Object) {
+	switch tt := t.(type) {
+	case *Object:
+		objs = append(objs, tt)
+	case *Interface:
+		for _, pt := range tt.possibleTypes().list {
+			objs = append(objs, pt.(*Object))
+		}
+	case *Union:
+		for _, m := range tt.Members {
+			if obj, ok := m.(*Object); ok {
+				objs = append(objs, obj)
+			}
+		}
+	}
+	return
+}
+
+func mockWord(h uint64) string {
+	w1 := mockWords[h%uint64(len(mockWords))]
+	w2 := mockWords[(h>>8)%uint64(len(mockWords))]
+
+	return strings.Title(w1) + " " + w2
+}
+
+func mockTime(h uint64) time.Time {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	return start.Add(time.Duration(h%(5*365*24*3600)) * time.Second)
+}
+
+// mockScalar returns a value for a custom scalar. A few kinds of values are
+// tried and the first one the scalar accepts is used.
+func mockScalar(t Type, h uint64) interface{} {
+	word := mockWords[h%uint64(len(mockWords))]
+	candidates := []interface{}{
+		mockWord(h),
+		fmt.Sprintf("%08x-%04x-4%03x-8%03x-%012x", uint32(h), uint16(h>>32), h>>48&0xfff, h>>20&0xfff, h&0xffffffffffff),
+		word + "@example.com",
+		"https://example.com/" + word,
+		mockTime(h),
+		int64(h % 1000),
+		float64(h%100000) / 100.0,
+		h&1 == 1,
+	}
+	co, _ := t.(OutCoercer)
+	if co == nil {
+		return candidates[0]
+	}
+	for _, v := range candidates {
+		if _, err := co.CoerceOut(v); err == nil {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+const mockSDL = `
+enum Genre { POP ROCK JAZZ }
+interface Named { name: String! }
+type Artist implements Named { name: String! songs: [Song!]! origin: String }
+type Song implements Named { name: String! length: Int when: Time genre: Genre id: ID rating: Float hit: Boolean }
+union Any = Artist | Song
+type Query { artist: Artist songs(first: Int): [Song] named: [Named] any: [Any] }
+`
+
+func mockResolve(t *testing.T, opts *ggql.MockOptions, src string) string {
+	root := ggql.NewMockRoot(opts)
+	err := root.ParseString(mockSDL)
+	checkNil(t, err, "parse failed. %s", err)
+
+	result := root.ResolveString(src, "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, 2)
+
+	return b.String()
+}
+
+func TestMockRoot(t *testing.T) {
+	ggql.Sort = true
+	src := `{
+  songs(first: 2) { name length when genre id rating hit }
+  named { __typename name ... on Song { length } }
+  any { __typename ... on Artist { name } ... on Song { id } }
+  __type(name: "Genre") { kind }
+}`
+	opts := &ggql.MockOptions{Seed: 7, ListLen: 2}
+	actual := mockResolve(t, opts, src)
+	checkEqual(t, `{
+  "data": {
+    "__type": {
+      "kind": "ENUM"
+    },
+    "any": [
+      {
+        "__typename": "Artist",
+        "name": "Pepper velvet"
+      },
+      {
+        "__typename": "Song",
+        "id": "677fc98e"
+      }
+    ],
+    "named": [
+      {
+        "__typename": "Song",
+        "length": 619,
+        "name": "Indigo onyx"
+      },
+      {
+        "__typename": "Artist",
+        "name": "Harbor velvet"
+      }
+    ],
+    "songs": [
+      {
+        "genre": "POP",
+        "hit": true,
+        "id": "f3c64d87",
+        "length": 94,
+        "name": "Bravo indigo",
+        "rating": 299.39,
+        "when": "2023-08-11T17:23:30Z"
+      },
+      {
+        "genre": "JAZZ",
+        "hit": false,
+        "id": "acbd8694",
+        "length": 873,
+        "name": "Cedar velvet",
+        "rating": 779.72,
+        "when": "2023-10-23T10:37:17Z"
+      }
+    ]
+  }
+}
+`, actual, "mock result mismatch")
+
+	checkEqual(t, actual, mockResolve(t, opts, src), "same seed should give the same result")
+	checkEqual(t, false, actual == mockResolve(t, &ggql.MockOptions{Seed: 8, ListLen: 2}, src),
+		"different seed should give a different result")
+}
+
+func TestMockRootUnionTypename(t *testing.T) {
+	ggql.Sort = true
+	src := `{any { __typename ... on Artist { t: __typename name } ... on Song { t: __typename id } }}`
+	actual := mockResolve(t, &ggql.MockOptions{Seed: 7, ListLen: 2}, src)
+	checkEqual(t, `{
+  "data": {
+    "any": [
+      {
+        "__typename": "Artist",
+        "name": "Pepper velvet",
+        "t": "Artist"
+      },
+      {
+        "__typename": "Song",
+        "id": "677fc98e",
+        "t": "Song"
+      }
+    ]
+  }
+}
+`, actual, "mock result mismatch")
+}
+
+func TestMockRootFixtures(t *testing.T) {
+	ggql.Sort = true
+	opts := &ggql.MockOptions{
+		Fixtures: map[string]interface{}{
+			"Artist": map[string]interface{}{
+				"name":  "Fazerdaze",
+				"songs": []interface{}{map[string]interface{}{"name": "Jennifer"}},
+			},
+			"Genre": []interface{}{"POP"},
+			"Named": map[string]interface{}{"__typename": "Song", "name": "Lucky Girl"},
+		},
+	}
+	actual := mockResolve(t, opts, `{
+  artist { name songs { name genre } }
+  songs { genre }
+  named { __typename name }
+}`)
+	checkEqual(t, `{
+  "data": {
+    "artist": {
+      "name": "Fazerdaze",
+      "songs": [
+        {
+          "genre": "POP",
+          "name": "Jennifer"
+        }
+      ]
+    },
+    "named": [
+      {
+        "__typename": "Song",
+        "name": "Lucky Girl"
+      },
+      {
+        "__typename": "Song",
+        "name": "Lucky Girl"
+      },
+      {
+        "__typename": "Song",
+        "name": "Lucky Girl"
+      }
+    ],
+    "songs": [
+      {
+        "genre": "POP"
+      },
+      {
+        "genre": "POP"
+      },
+      {
+        "genre": "POP"
+      }
+    ]
+  }
+}
+`, actual, "mock fixture result mismatch")
+}
@@ -91,9 +91,15 @@ func (t *NonNull) Validate(root *Root) (errs []error) {
 
 // CoerceIn coerces an input value into the expected input type if possible
 // otherwise an error is returned.
+// The package Relaxed variable determines if the relaxed coercion rules
+// are used. Requests use the Relaxed option of the Root instead.
 func (t *NonNull) CoerceIn(v interface{}) (interface{}, error) {
+	return t.coerceIn(v, Relaxed)
+}
+
+func (t *NonNull) coerceIn(v interface{}, relaxed bool) (interface{}, error) {
 	if co, _ := t.Base.(InCoercer); co != nil && v != nil {
-		return co.CoerceIn(v)
+		return coerceIn(co, v, relaxed)
 	}
 	return nil, newCoerceErr(v, t.Name())
 }
@@ -116,6 +122,7 @@ func (t *NonNull) CoerceOut(v interface{}) (interface{}, error) {
 //   enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
 //   inputfields: [__InputValue!]
 //   ofType: __Type
+//   specifiedByURL: String
 func (t *NonNull) Resolve(field *Field, args map[string]interface{}) (interface{}, error) {
 	switch field.Name {
 	case kindStr, descriptionStr:
@@ -124,7 +131,7 @@ func (t *NonNull) Resolve(field *Field, args map[string]interface{}) (interface{
 		return t.Name(), nil
 	case ofTypeStr:
 		return t.Base, nil
-	case interfacesStr, fieldsStr, possibleTypesStr, enumValuesStr, inputFieldsStr:
+	case interfacesStr, fieldsStr, possibleTypesStr, enumValuesStr, inputFieldsStr, specifiedByURLStr:
 		return nil, nil
 	}
 	return nil, fmt.Errorf("type __Type does not have field %s", field)
@@ -115,33 +115,52 @@ func (t *Object) GetField(name string) *FieldDef {
 
 // Validate a type.
 func (t *Object) Validate(root *Root) (errs []error) {
-	for _, it := range t.Interfaces {
-		if i, ok := it.(*Interface); ok {
-			errs = append(errs, t.validateInterface(i)...)
-		} else {
-			errs = append(errs, fmt.Errorf("%w, %s is not an interface for %s at %d:%d",
-				ErrValidation, it.Name(), t.Name(), t.line, t.col))
-		}
-	}
+	errs = append(errs, t.validateImplements(&t.fields, t.Interfaces)...)
 	return append(errs, t.validateFieldDefs(t.Name(), &t.fields)...)
 }
 
-func (t *Object) validateInterface(i *Interface) (errs []error) {
-	for name, fi := range i.fields.dict {
-		fo := t.fields.get(name)
-		if fo == nil {
-			errs = append(errs, fmt.Errorf("%w, %s is missing field %s from interface %s at %d:%d",
-				ErrValidation, t.Name(), name, i.Name(), t.line, t.col))
+// validateImplements checks that each of the interfaces is an interface,
+// that the fields satisfy the interface fields, and that the interfaces
+// implemented by each interface are also listed.
+func (b *Base) validateImplements(fields *fieldList, interfaces []Type) (errs []error) {
+	for _, it := range interfaces {
+		i, ok := it.(*Interface)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w, %s is not an interface for %s at %d:%d",
+				ErrValidation, it.Name(), b.Name(), b.line, b.col))
 			continue
 		}
-		errs = append(errs, t.validateField(fo, fi, i.Name())...)
+		for name, fi := range i.fields.dict {
+			fo := fields.get(name)
+			if fo == nil {
+				errs = append(errs, fmt.Errorf("%w, %s is missing field %s from interface %s at %d:%d",
+					ErrValidation, b.Name(), name, i.Name(), b.line, b.col))
+				continue
+			}
+			errs = append(errs, validateField(fo, fi, i.Name())...)
+		}
+		for _, ii := range i.Interfaces {
+			if !hasType(interfaces, ii) {
+				errs = append(errs, fmt.Errorf("%w, %s must also implement %s since %s implements %s at %d:%d",
+					ErrValidation, b.Name(), ii.Name(), i.Name(), ii.Name(), b.line, b.col))
+			}
+		}
 	}
 	return
 }
 
+func hasType(list []Type, t Type) bool {
+	for _, x := range list {
+		if x.Name() == t.Name() {
+			return true
+		}
+	}
+	return false
+}
+
 // validateField checks the object FieldDef against the interface FieldDef.
-func (t *Object) validateField(fo, fi *FieldDef, iName string) (errs []error) {
-	if !t.isSubType(fi.Type, fo.Type) {
+func validateField(fo, fi *FieldDef, iName string) (errs []error) {
+	if !isSubType(fi.Type, fo.Type) {
 		errs = append(errs, fmt.Errorf("%w, interface %s not satisfied, field %s return type %s is not a sub-type of %s at %d:%d",
 			ErrValidation, iName, fi.Name(), fo.Type.Name(), fi.Type.Name(), fo.line, fo.col))
 	}
@@ -166,7 +185,7 @@ func (t *Object) validateField(fo, fi *FieldDef, iName string) (errs []error) {
 	return
 }
 
-func (t *Object) isSubType(target, sub Type) bool {
+func isSubType(target, sub Type) bool {
 	if typeEqual(target, sub) {
 		return true
 	}
@@ -183,20 +202,14 @@ func (t *Object) isSubType(target, sub Type) bool {
 			}
 		}
 	case *Interface:
-		if ot, _ := sub.(*Object); ot != nil {
-			for _, i := range ot.Interfaces {
-				if typeEqual(i, target) {
-					return true
-				}
-			}
-		}
+		return tt.isImplementedBy(sub)
 	case *List:
 		if list, _ := sub.(*List); list != nil {
-			return t.isSubType(tt.Base, list.Base)
+			return isSubType(tt.Base, list.Base)
 		}
 	case *NonNull:
 		if nn, _ := sub.(*NonNull); nn != nil {
-			return t.isSubType(tt.Base, nn.Base)
+			return isSubType(tt.Base, nn.Base)
 		}
 	}
 	return false
@@ -217,6 +230,7 @@ func (t *Object) AddField(fd *FieldDef) error {
 //   enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
 //   inputfields: [__InputValue!]
 //   ofType: __Type
+//   specifiedByURL: String
 func (t *Object) Resolve(field *Field, args map[string]interface{}) (result interface{}, err error) {
 	switch field.Name {
 	case kindStr:
@@ -243,7 +257,7 @@ func (t *Object) Resolve(field *Field, args map[string]interface{}) (result inte
 		}
 	case interfacesStr:
 		result = t.Interfaces
-	case possibleTypesStr, enumValuesStr, inputFieldsStr, ofTypeStr:
+	case possibleTypesStr, enumValuesStr, inputFieldsStr, ofTypeStr, specifiedByURLStr:
 		// nil result
 	}
 	return
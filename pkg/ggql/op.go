@@ -44,9 +44,7 @@ func (op *Op) String() string {
 // Validate an operation.
 func (op *Op) Validate(root *Root) (errs []error) {
 	errs = append(errs, op.SelBase.Validate(root)...)
-	for _, du := range op.Directives() {
-		errs = append(errs, root.validateDirUse(op.Name, Locate(op), du)...)
-	}
+	errs = append(errs, root.validateDirUseList(op.Name, Locate(op), op.Directives())...)
 	for _, v := range op.Variables {
 		errs = append(errs, v.Validate(root)...)
 	}
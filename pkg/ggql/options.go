@@ -0,0 +1,104 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql
+
+import "fmt"
+
+// Options control how a Root evaluates requests. The options of a Root are
+// set with SetOptions() and can be replaced for a single request with
+// ResolvePlanOptions() or StreamPlanOptions().
+type Options struct {
+	// Relaxed if true relaxes coercion rules so that a string can be
+	// coerced into an enum value.
+	Relaxed bool
+
+	// MaxResolveDepth is the maximum depth to allow during resolving. If
+	// zero or less the package MaxResolveDepth is used.
+	MaxResolveDepth int
+
+	// Ordered if true forms the objects in results as *OrderedMap values
+	// that keep the order of the selection set instead of as
+	// map[string]interface{} values.
+	Ordered bool
+
+	// Sort if true forms the objects in results as *OrderedMap values with
+	// the members sorted by name. It is not set by the package Sort
+	// variable which only sorts map values when they are written.
+	Sort bool
+}
+
+// DefaultOptions returns the options used by a Root that has not had
+// options set. The Relaxed and MaxResolveDepth values are taken from the
+// package variables of the same name. The package Sort variable is not used
+// since it only controls the order in which map values are written.
+func DefaultOptions() Options {
+	return Options{Relaxed: Relaxed, MaxResolveDepth: MaxResolveDepth}
+}
+
+// SetOptions sets the options used when evaluating requests. Options
+// should be set before any requests are evaluated.
+func (root *Root) SetOptions(opts Options) {
+	root.opts = &opts
+}
+
+// Options returns the options used when evaluating requests.
+func (root *Root) Options() Options {
+	if root.opts != nil {
+		return *root.opts
+	}
+	return DefaultOptions()
+}
+
+// execution is the state of a single evaluation of a plan.
+type execution struct {
+	vars     map[string]interface{}
+	opts     Options
+	maxDepth int
+
+	// cache policy of the fields resolved so far, a maxAge of -1
+	// indicates no field has limited the maxAge
+	defaultMaxAge int
+	maxAge        int
+	private       bool
+}
+
+func (root *Root) newExecution(vars map[string]interface{}, opts *Options) *execution {
+	ex := execution{vars: vars, opts: root.requestOptions(opts), defaultMaxAge: root.DefaultMaxAge, maxAge: -1}
+	ex.maxDepth = ex.opts.maxDepth()
+
+	return &ex
+}
+
+// requestOptions returns the options for a request which are the options
+// provided or the options of the root if opts is nil.
+func (root *Root) requestOptions(opts *Options) Options {
+	if opts != nil {
+		return *opts
+	}
+	return root.Options()
+}
+
+func (o *Options) maxDepth() int {
+	if 0 < o.MaxResolveDepth {
+		return o.MaxResolveDepth
+	}
+	return MaxResolveDepth
+}
+
+// cacheKey returns the options that change a response in a form that can be
+// added to a response cache key.
+func (o *Options) cacheKey() string {
+	return fmt.Sprintf(" %t %d %t %t", o.Relaxed, o.maxDepth(), o.Ordered, o.Sort)
+}
@@ -0,0 +1,125 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+func TestOptionsDefault(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	opts := root.Options()
+	checkEqual(t, ggql.Relaxed, opts.Relaxed, "default Relaxed")
+	checkEqual(t, ggql.MaxResolveDepth, opts.MaxResolveDepth, "default MaxResolveDepth")
+	checkEqual(t, false, opts.Ordered, "default Ordered")
+
+	root.SetOptions(ggql.Options{Relaxed: true, MaxResolveDepth: 10})
+	opts = root.Options()
+	checkEqual(t, true, opts.Relaxed, "set Relaxed")
+	checkEqual(t, 10, opts.MaxResolveDepth, "set MaxResolveDepth")
+}
+
+func TestOptionsRelaxed(t *testing.T) {
+	strict := setupBindEnum(t)
+	relaxed := setupBindEnum(t)
+	relaxed.SetOptions(ggql.Options{Relaxed: true})
+
+	src := `query q($g: Genre, $list: [Genre]) {genre(g: $g) genres(list: $list)}`
+	vars := map[string]interface{}{"g": "ROCK", "list": []interface{}{"JAZZ", "POP"}}
+	expect := `{"data":{"genre":"ROCK","genres":["JAZZ","POP"]}}`
+
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, relaxed.ResolveString(src, "q", vars), -1)
+	checkEqual(t, expect, b.String(), "relaxed root should coerce strings to enum values")
+
+	vars = map[string]interface{}{"g": "ROCK", "list": []interface{}{"JAZZ", "POP"}}
+	result := strict.ResolveString(src, "q", vars)
+	checkNotNil(t, result["errors"], "strict root should not coerce strings to enum values")
+
+	exe, err := strict.ParseExecutableString(src)
+	checkNil(t, err, "parse failed. %s", err)
+	plan, err := strict.Compile(exe, "q")
+	checkNil(t, err, "compile failed. %s", err)
+	vars = map[string]interface{}{"g": "ROCK", "list": []interface{}{"JAZZ", "POP"}}
+	res, err := strict.ResolvePlanOptions(plan, vars, &ggql.Options{Relaxed: true})
+	checkNil(t, err, "resolve with relaxed options failed. %s", err)
+	b.Reset()
+	_ = ggql.WriteJSONValue(&b, res, -1)
+	checkEqual(t, expect, b.String(), "request options should replace the root options")
+}
+
+func TestOptionsRelaxedDefault(t *testing.T) {
+	root := setupBindEnum(t)
+	err := root.ParseString(`extend type Query { loose(g: Genre = "ROCK"): Genre }`)
+	checkNil(t, err, "extend should not fail. %s", err)
+	err = root.RegisterType(&BindQuery{}, "Query")
+	checkNil(t, err, "RegisterType should not fail. %s", err)
+	err = root.RegisterField("Query", "loose", "Genre")
+	checkNil(t, err, "RegisterField should not fail. %s", err)
+
+	result := root.ResolveString(`{loose}`, "", nil)
+	checkNotNil(t, result["errors"], "strict root should not coerce a string default to an enum value")
+
+	root.SetOptions(ggql.Options{Relaxed: true})
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, root.ResolveString(`{loose}`, "", nil), -1)
+	checkEqual(t, `{"data":{"loose":"ROCK"}}`, b.String(), "relaxed root should coerce a string default to an enum value")
+}
+
+func TestOptionsMaxResolveDepth(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	root.SetOptions(ggql.Options{MaxResolveDepth: 2})
+	result := root.ResolveString(`{artists{songs{name}}}`, "", nil)
+	data, _ := result["data"].(map[string]interface{})
+	artists, _ := data["artists"].([]interface{})
+	checkEqual(t, 2, len(artists), "artists length")
+	_, isMap := artists[0].(map[string]interface{})
+	checkEqual(t, false, isMap, "artists beyond the maximum depth should not be resolved")
+
+	root.SetOptions(ggql.Options{})
+	result = root.ResolveString(`{artists{songs{name}}}`, "", nil)
+	data, _ = result["data"].(map[string]interface{})
+	artists, _ = data["artists"].([]interface{})
+	_, isMap = artists[0].(map[string]interface{})
+	checkEqual(t, true, isMap, "zero MaxResolveDepth should use the package MaxResolveDepth")
+}
+
+func TestOptionsSort(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	ggql.Sort = false
+	defer func() { ggql.Sort = true }()
+
+	req := ggql.Request{
+		Query:   `{artist(name: "Fazerdaze") {origin name}}`,
+		Options: &ggql.Options{Sort: true},
+	}
+	result := root.ResolveRequest(&req)
+	data, _ := result["data"].(*ggql.OrderedMap)
+	checkNotNil(t, data, "data should be an OrderedMap")
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, data, -1)
+	checkEqual(t, `{"artist":{"name":"Fazerdaze","origin":["Morningside","Auckland","New Zealand"]}}`,
+		b.String(), "sorted result")
+
+	req.Options = &ggql.Options{Ordered: true}
+	result = root.ResolveRequest(&req)
+	b.Reset()
+	_ = ggql.WriteJSONValue(&b, result["data"], -1)
+	checkEqual(t, `{"artist":{"origin":["Morningside","Auckland","New Zealand"],"name":"Fazerdaze"}}`,
+		b.String(), "ordered result")
+}
@@ -0,0 +1,96 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql
+
+import (
+	"bytes"
+)
+
+// OrderedMap is an object that keeps its members in the order they were
+// added. When the Ordered option of a Root is true the objects in a result
+// are *OrderedMap values in the order of the selection set.
+type OrderedMap struct {
+	// Keys of the members in order.
+	Keys []string
+
+	// Values of the members in the same order as the Keys.
+	Values []interface{}
+}
+
+// Len returns the number of members.
+func (om *OrderedMap) Len() int {
+	return len(om.Keys)
+}
+
+// Get the value of a member. False is returned if there is no member with
+// the key.
+func (om *OrderedMap) Get(key string) (interface{}, bool) {
+	for i, k := range om.Keys {
+		if k == key {
+			return om.Values[i], true
+		}
+	}
+	return nil, false
+}
+
+// Set the value of a member. A new member is added at the end.
+func (om *OrderedMap) Set(key string, value interface{}) {
+	for i, k := range om.Keys {
+		if k == key {
+			om.Values[i] = value
+			return
+		}
+	}
+	om.Keys = append(om.Keys, key)
+	om.Values = append(om.Values, value)
+}
+
+// Map returns the members as a map[string]interface{}. Nested OrderedMap
+// values are not converted.
+func (om *OrderedMap) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(om.Keys))
+	for i, k := range om.Keys {
+		m[k] = om.Values[i]
+	}
+	return m
+}
+
+// MarshalJSON writes the members as a JSON object in order so that an
+// OrderedMap can be used with the encoding/json package.
+func (om *OrderedMap) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	if err := writeMembers(&b, om.Keys, om.Values, false, 0, -1); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// byKey sorts the members of an OrderedMap by key.
+type byKey struct {
+	om *OrderedMap
+}
+
+func (b byKey) Len() int {
+	return len(b.om.Keys)
+}
+
+func (b byKey) Less(i, j int) bool {
+	return b.om.Keys[i] < b.om.Keys[j]
+}
+
+func (b byKey) Swap(i, j int) {
+	b.om.Keys[i], b.om.Keys[j] = b.om.Keys[j], b.om.Keys[i]
+	b.om.Values[i], b.om.Values[j] = b.om.Values[j], b.om.Values[i]
+}
@@ -0,0 +1,75 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+func TestOrderedMap(t *testing.T) {
+	var om ggql.OrderedMap
+	om.Set("b", 1)
+	om.Set("a", []interface{}{true, nil})
+	om.Set("c", &ggql.OrderedMap{Keys: []string{"z", "y"}, Values: []interface{}{"x", 2.5}})
+	om.Set("b", 3)
+
+	checkEqual(t, 3, om.Len(), "length")
+	v, has := om.Get("b")
+	checkEqual(t, true, has, "b should be found")
+	checkEqual(t, 3, v, "replaced value")
+	_, has = om.Get("d")
+	checkEqual(t, false, has, "d should not be found")
+	checkEqual(t, 3, len(om.Map()), "map length")
+
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, &om, -1)
+	checkEqual(t, `{"b":3,"a":[true,null],"c":{"z":"x","y":2.5}}`, b.String(), "JSON order")
+
+	b.Reset()
+	_ = ggql.WriteSDLValue(&b, &om)
+	checkEqual(t, `{b: 3, a: [true, null], c: {z: "x", y: 2.5}}`, b.String(), "SDL order")
+
+	j, err := json.Marshal(&om)
+	checkNil(t, err, "marshal failed. %s", err)
+	checkEqual(t, `{"b":3,"a":[true,null],"c":{"z":"x","y":2.5}}`, string(j), "marshal order")
+}
+
+func TestOrderedResults(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	root.SetOptions(ggql.Options{Ordered: true})
+	src := `{
+  artist(name: "Fazerdaze") {
+    origin
+    songs {duration}
+    ... on Artist {name songs {name}}
+  }
+  title
+}`
+	result := root.ResolveString(src, "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, `{"data":{"artist":{"origin":["Morningside","Auckland","New Zealand"],"songs":[`+
+		`{"duration":240,"name":"Jennifer"},{"duration":170,"name":"Lucky Girl"},`+
+		`{"duration":194,"name":"Friends"},{"duration":193,"name":"Reel"}],"name":"Fazerdaze"},"title":"Songs"}}`,
+		b.String(), "result should follow the selection set order")
+
+	data, _ := result["data"].(*ggql.OrderedMap)
+	checkNotNil(t, data, "data should be an OrderedMap")
+	checkEqual(t, "artist title", strings.Join(data.Keys, " "), "data keys")
+}
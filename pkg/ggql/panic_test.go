@@ -0,0 +1,160 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+const panicSdl = `
+type Query {
+  a: Int
+  boom: Int
+  items: [Item]
+  odds: [Odd]
+  b: String
+}
+
+type Item {
+  name: String
+  bad: Int
+}
+
+scalar Odd
+`
+
+type panicSchema struct {
+	Query panicQuery
+}
+
+type panicQuery struct{}
+
+type panicItem struct {
+	name string
+}
+
+type oddScalar struct {
+	ggql.Scalar
+}
+
+func (s *panicSchema) Resolve(field *ggql.Field, args map[string]interface{}) (interface{}, error) {
+	if field.Name == "query" {
+		return &s.Query, nil
+	}
+	return nil, fmt.Errorf("type Schema does not have field %s", field)
+}
+
+func (q *panicQuery) Resolve(field *ggql.Field, args map[string]interface{}) (interface{}, error) {
+	switch field.Name {
+	case "a":
+		return 1, nil
+	case "boom":
+		panic("boom")
+	case "items":
+		return []interface{}{&panicItem{name: "x"}, &panicItem{name: "y"}}, nil
+	case "odds":
+		return []interface{}{1, 2, 3}, nil
+	case "b":
+		return "b", nil
+	}
+	return nil, fmt.Errorf("type Query does not have field %s", field)
+}
+
+func (item *panicItem) Resolve(field *ggql.Field, args map[string]interface{}) (interface{}, error) {
+	switch field.Name {
+	case "name":
+		return item.name, nil
+	case "bad":
+		if item.name == "y" {
+			var m map[string]int
+			m["crash"] = 1
+		}
+		return 0, nil
+	}
+	return nil, fmt.Errorf("type Item does not have field %s", field)
+}
+
+func (t *oddScalar) CoerceOut(v interface{}) (interface{}, error) {
+	if v == 2 {
+		panic(errors.New("even"))
+	}
+	return v, nil
+}
+
+func setupPanicRoot(t *testing.T) *ggql.Root {
+	root := ggql.NewRoot(&panicSchema{})
+	err := root.AddTypes(&oddScalar{ggql.Scalar{Base: ggql.Base{N: "Odd"}}})
+	checkNil(t, err, "no error should be returned when adding a scalar. %s", err)
+	err = root.ParseString(panicSdl)
+	checkNil(t, err, "no error should be returned when parsing a valid SDL. %s", err)
+	return root
+}
+
+func TestPanicResolve(t *testing.T) {
+	ggql.Sort = true
+	root := setupPanicRoot(t)
+	result := root.ResolveString(`{a boom items{name bad} odds b}`, "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, `{"data":{"a":1,"b":"b","boom":null,"items":[{"bad":0,"name":"x"},{"bad":null,"name":"y"}],"odds":null},`+
+		`"errors":[{"locations":[{"column":5,"line":1}],"message":"panic: boom","path":["boom"]},`+
+		`{"locations":[{"column":21,"line":1}],"message":"panic: assignment to entry in nil map","path":["items",1,"bad"]},`+
+		`{"locations":[{"column":26,"line":1}],"message":"panic: even","path":["odds"]}]}`,
+		b.String(), "panics should be field errors")
+}
+
+func TestPanicStream(t *testing.T) {
+	root := setupPanicRoot(t)
+	var b strings.Builder
+	err := root.StreamString(&b, `{a boom items{name bad} odds b}`, "", nil)
+	checkNil(t, err, "stream failed. %s", err)
+	var v interface{}
+	err = json.Unmarshal([]byte(b.String()), &v)
+	checkNil(t, err, "streamed JSON is not valid. %s\n%s", err, b.String())
+	// The list values written before the panic can not be taken back so
+	// the list is closed after a null element.
+	checkEqual(t, `{"data":{"a":1,"boom":null,"items":[{"name":"x","bad":0},{"name":"y","bad":null}],"odds":[1,null],"b":"b"},`+
+		`"errors":[{"locations":[{"column":5,"line":1}],"message":"panic: boom","path":["boom"]},`+
+		`{"locations":[{"column":21,"line":1}],"message":"panic: assignment to entry in nil map","path":["items",1,"bad"]},`+
+		`{"locations":[{"column":26,"line":1}],"message":"panic: even","path":["odds"]}]}`,
+		b.String(), "streamed panics should be field errors")
+}
+
+func TestPanicHook(t *testing.T) {
+	root := setupPanicRoot(t)
+	var fields []string
+	var stack []byte
+	root.PanicHook = func(field *ggql.Field, value interface{}, s []byte) {
+		fields = append(fields, fmt.Sprintf("%s:%v", field.Name, value))
+		stack = s
+	}
+	root.ErrorCodes = true
+	result := root.ResolveString(`{a boom}`, "", nil)
+	checkEqual(t, "boom:boom", strings.Join(fields, ","), "hook fields")
+	checkEqual(t, true, strings.Contains(string(stack), "panicQuery"), "stack should include the resolver. %s", stack)
+
+	errs, _ := result["errors"].([]interface{})
+	checkEqual(t, 1, len(errs), "error count")
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, errs[0], -1)
+	checkEqual(t, `{"extensions":{"code":"INTERNAL_SERVER_ERROR"},"locations":[{"column":5,"line":1}],"message":"panic: boom","path":["boom"]}`,
+		b.String(), "panic error code")
+}
@@ -82,6 +82,9 @@ type parser struct {
 	col    int
 	onDeck byte
 	eof    bool
+
+	// comment, if not nil, is called with each comment skipped.
+	comment func(line int, text string)
 }
 
 // ParseValue parses a reader into a value where the input follows the SDL
@@ -176,14 +179,8 @@ func (p *parser) skipSpace() (b byte, err error) {
 			continue
 		}
 		if b == '#' {
-			// Read to end of line.
-			for {
-				if b, err = p.readByte(); err != nil || b == 0 {
-					return
-				}
-				if b == '\n' {
-					break
-				}
+			if b, err = p.skipComment(); err != nil || b == 0 {
+				return
 			}
 		} else {
 			p.putBack(b)
@@ -193,6 +190,24 @@ func (p *parser) skipSpace() (b byte, err error) {
 	return
 }
 
+// Skips a comment up to the end of the line and returns the last byte read.
+func (p *parser) skipComment() (b byte, err error) {
+	var buf []byte
+	line := p.line
+	for {
+		if b, err = p.readByte(); err != nil || b == 0 || b == '\n' {
+			break
+		}
+		if p.comment != nil {
+			buf = append(buf, b)
+		}
+	}
+	if err == nil && p.comment != nil {
+		p.comment(line, strings.TrimRight("#"+string(buf), " \t\r"))
+	}
+	return
+}
+
 func (p *parser) readToken() (string, error) {
 	b, err := p.skipSpace()
 	if err != nil || b == 0 {
@@ -212,6 +227,19 @@ func (p *parser) readToken() (string, error) {
 	}
 }
 
+// readTokenAt reads a token and returns it with the line and column of the
+// first character of the token. The position can not be determined after
+// the token is read as the character that ends the token may be a newline.
+func (p *parser) readTokenAt() (token string, line, col int, err error) {
+	var b byte
+	if b, err = p.skipSpace(); err == nil && b != 0 {
+		line = p.line
+		col = p.col - 1
+		token, err = p.readToken()
+	}
+	return
+}
+
 func (p *parser) readNumberToken() (string, error) {
 	var buf bytes.Buffer
 	for {
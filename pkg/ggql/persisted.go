@@ -0,0 +1,131 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// PersistedQueryStore is the interface for storing persisted queries keyed
+// by the hex encoded SHA-256 hash of the query.
+type PersistedQueryStore interface {
+
+	// Get the query for a hash. If the hash is not in the store an error
+	// that wraps ErrNotFound should be returned.
+	Get(hash string) (string, error)
+
+	// Put a query in the store.
+	Put(hash string, query string) error
+}
+
+// QueryHash returns the hex encoded SHA-256 hash of a query as used by
+// persisted query stores.
+func QueryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// MemoryQueryStore is a PersistedQueryStore that keeps queries in memory.
+type MemoryQueryStore struct {
+	queries map[string]string
+	mu      sync.RWMutex
+}
+
+// NewMemoryQueryStore returns a new MemoryQueryStore with the queries
+// provided already in the store.
+func NewMemoryQueryStore(queries ...string) *MemoryQueryStore {
+	qs := MemoryQueryStore{queries: map[string]string{}}
+	for _, q := range queries {
+		qs.queries[QueryHash(q)] = q
+	}
+	return &qs
+}
+
+// Get the query for a hash.
+func (qs *MemoryQueryStore) Get(hash string) (string, error) {
+	qs.mu.RLock()
+	defer qs.mu.RUnlock()
+
+	if q, has := qs.queries[hash]; has {
+		return q, nil
+	}
+	return "", fmt.Errorf("%w: persisted query %s", ErrNotFound, hash)
+}
+
+// Put a query in the store.
+func (qs *MemoryQueryStore) Put(hash string, query string) error {
+	qs.mu.Lock()
+	qs.queries[hash] = query
+	qs.mu.Unlock()
+
+	return nil
+}
+
+// FileQueryStore is a PersistedQueryStore that keeps each query in a file
+// named by the hash with a .graphql extension in a directory.
+type FileQueryStore struct {
+	dir string
+}
+
+// NewFileQueryStore returns a new FileQueryStore that uses dir which is
+// created if it does not exist.
+func NewFileQueryStore(dir string) (*FileQueryStore, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	return &FileQueryStore{dir: dir}, nil
+}
+
+// Get the query for a hash.
+func (qs *FileQueryStore) Get(hash string) (string, error) {
+	path, err := qs.path(hash)
+	if err != nil {
+		return "", err
+	}
+	var src []byte
+	if src, err = ioutil.ReadFile(path); err != nil {
+		if os.IsNotExist(err) {
+			err = fmt.Errorf("%w: persisted query %s", ErrNotFound, hash)
+		}
+		return "", err
+	}
+	return string(src), nil
+}
+
+// Put a query in the store.
+func (qs *FileQueryStore) Put(hash string, query string) error {
+	path, err := qs.path(hash)
+	if err == nil {
+		err = ioutil.WriteFile(path, []byte(query), 0600)
+	}
+	return err
+}
+
+// path returns the file path for a hash after checking that the hash is a
+// hex encoded SHA-256 hash so that it can not be used to access other
+// files.
+func (qs *FileQueryStore) path(hash string) (string, error) {
+	if b, err := hex.DecodeString(hash); err != nil || len(b) != sha256.Size {
+		return "", fmt.Errorf("%w, %q is not a SHA-256 hash", ErrPersistedQuery, hash)
+	}
+	return filepath.Join(qs.dir, hash+".graphql"), nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+const pqQuery = `{artist(name: "Fazerdaze"){name}}`
+
+func pqResolve(root *ggql.Root, req string) string {
+	var r ggql.Request
+	_ = json.Unmarshal([]byte(req), &r)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, root.ResolveRequest(&r), -1)
+
+	return b.String()
+}
+
+func TestPersistedQueryAPQ(t *testing.T) {
+	ggql.Sort = true
+	var log strings.Builder
+	root := setupTestSongs(t, &log)
+	root.PersistedQueries = ggql.NewMemoryQueryStore()
+	hash := ggql.QueryHash(pqQuery)
+
+	hashOnly := `{"extensions":{"persistedQuery":{"version":1,"sha256Hash":"` + hash + `"}}}`
+	checkEqual(t,
+		`{"errors":[{"extensions":{"code":"PERSISTED_QUERY_NOT_FOUND"},"message":"PersistedQueryNotFound"}]}`,
+		pqResolve(root, hashOnly), "hash only before registration")
+
+	withQuery := `{"query":` + string(mustJSON(pqQuery)) + `,"extensions":{"persistedQuery":{"version":1,"sha256Hash":"` + hash + `"}}}`
+	checkEqual(t, `{"data":{"artist":{"name":"Fazerdaze"}}}`, pqResolve(root, withQuery), "query with hash")
+	checkEqual(t, `{"data":{"artist":{"name":"Fazerdaze"}}}`, pqResolve(root, hashOnly), "hash only after registration")
+
+	bad := `{"query":"{title}","extensions":{"persistedQuery":{"version":1,"sha256Hash":"` + hash + `"}}}`
+	checkEqual(t,
+		`{"errors":[{"extensions":{"code":"INVALID_PERSISTED_QUERY_HASH"},"message":"persisted query error: provided sha256Hash does not match the query"}]}`,
+		pqResolve(root, bad), "mismatched hash")
+
+	version := `{"extensions":{"persistedQuery":{"version":2,"sha256Hash":"` + hash + `"}}}`
+	checkEqual(t,
+		`{"errors":[{"extensions":{"code":"PERSISTED_QUERY_NOT_SUPPORTED"},"message":"persisted query error: persisted query version 2 is not supported"}]}`,
+		pqResolve(root, version), "unsupported version")
+}
+
+func TestPersistedQueryOnly(t *testing.T) {
+	ggql.Sort = true
+	var log strings.Builder
+	root := setupTestSongs(t, &log)
+	root.PersistedQueries = ggql.NewMemoryQueryStore(pqQuery)
+	root.PersistedOnly = true
+
+	checkEqual(t, `{"data":{"artist":{"name":"Fazerdaze"}}}`,
+		pqResolve(root, `{"query":`+string(mustJSON(pqQuery))+`}`), "allowed query")
+
+	other := `{artist(name: "Viagra Boys"){name}}`
+	notAllowed := `{"errors":[{"extensions":{"code":"PERSISTED_QUERY_NOT_ALLOWED"},"message":"persisted query error: query is not in the persisted query store"}]}`
+	checkEqual(t, notAllowed, pqResolve(root, `{"query":`+string(mustJSON(other))+`}`), "query not allowed")
+
+	withHash := `{"query":` + string(mustJSON(other)) + `,"extensions":{"persistedQuery":{"version":1,"sha256Hash":"` +
+		ggql.QueryHash(other) + `"}}}`
+	checkEqual(t, notAllowed, pqResolve(root, withHash), "query with hash not allowed")
+}
+
+func TestFileQueryStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ggql-pq")
+	checkNil(t, err, "temp dir failed. %s", err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	store, err := ggql.NewFileQueryStore(dir)
+	checkNil(t, err, "new file store failed. %s", err)
+
+	hash := ggql.QueryHash(pqQuery)
+	_, err = store.Get(hash)
+	checkNotNil(t, err, "get before put should fail")
+
+	err = store.Put(hash, pqQuery)
+	checkNil(t, err, "put failed. %s", err)
+
+	var q string
+	q, err = store.Get(hash)
+	checkNil(t, err, "get failed. %s", err)
+	checkEqual(t, pqQuery, q, "query from file store")
+
+	err = store.Put("../escape", pqQuery)
+	checkNotNil(t, err, "put with a bad hash should fail")
+}
+
+func mustJSON(v interface{}) []byte {
+	js, _ := json.Marshal(v)
+	return js
+}
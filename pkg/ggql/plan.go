@@ -0,0 +1,371 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Plan is an operation of an Executable compiled for evaluation. Field
+// definitions, argument types, directive conditions that do not depend on
+// variables, and fragment type conditions are all determined when the plan
+// is compiled so evaluating the plan, possibly many times and concurrently,
+// does less work than resolving the Executable directly. The Executable is
+// not modified when compiled or evaluated.
+type Plan struct {
+	op  *Op
+	exe *Executable
+	top *planField
+
+	// key is the normalized form of the operation used for response cache
+	// keys. It is only formed when needed.
+	once sync.Once
+	key  string
+}
+
+// planField is a compiled Field. The field is a copy of the Field in the
+// Executable with the ConType set and the arguments sorted. It is the field
+// passed to resolvers and is not modified once compiled.
+type planField struct {
+	field   *Field
+	fd      *FieldDef
+	ft      Type
+	meta    string // __typename, __type, or __schema
+	hasArgs bool
+	args    []planArg
+	missing []string
+	sels    *planSet          // compiled for the base type of the field
+	members map[Type]*planSet // compiled for each member of a union
+	fails   []error
+	hint    cacheHint
+
+	// acc holds a *reflectAcc for the most recent Go type resolved with
+	// reflection.
+	acc atomic.Value
+}
+
+type planArg struct {
+	av *ArgValue
+	t  Type
+}
+
+// planSet is a selection set compiled for a specific type.
+type planSet struct {
+	t    Type
+	leaf bool
+	sels []planSel
+
+	// flat is true if all the selections are unconditional fields with
+	// different response names.
+	flat bool
+}
+
+// planSel is a selection in a planSet. Selections with @skip or @include
+// conditions that use variables are dynamic and must be checked on each
+// evaluation. Selections skipped regardless of the variables are not
+// included in the planSet at all.
+type planSel struct {
+	sel     Selection
+	dynamic bool
+	field   *planField
+	frag    *planFrag
+
+	// fields is a slice of just field used when collecting fields.
+	fields []*planField
+}
+
+// planFrag is a compiled inline fragment or fragment reference. If the type
+// condition is satisfied by the container type then static is set.
+// Otherwise, if the container is an interface or union, the selections are
+// compiled for each object type that satisfies the condition.
+type planFrag struct {
+	ref    *FragRef
+	label  string
+	static *planSet
+	sets   map[*Object]*planSet
+}
+
+// reflectAcc describes how a field is resolved with reflection for a
+// specific Go type.
+type reflectAcc struct {
+	meta   reflect.Type
+	fd     *FieldDef
+	index  []int
+	method *reflect.Value
+}
+
+type compiler struct {
+	root   *Root
+	active map[*Fragment]bool
+	opType Type
+	err    error
+}
+
+// Compile an operation of an Executable into a Plan. If the operation name
+// is empty and there is only one operation in the Executable then that
+// operation is compiled.
+func (root *Root) Compile(exe *Executable, opName string) (*Plan, error) {
+	op := exe.Ops[opName]
+	if op == nil {
+		if len(exe.Ops) == 1 {
+			for _, o := range exe.Ops {
+				op = o
+				break
+			}
+		}
+		if op == nil {
+			return nil, fmt.Errorf("%w, could not determine operation to evaluate", ErrResolve)
+		}
+	}
+	c := compiler{root: root, active: map[*Fragment]bool{}}
+	if fd := root.getFieldDef(root.schema, string(op.Type)); fd != nil {
+		c.opType = BaseType(fd.Type)
+	}
+	field := Field{Alias: "data", Name: string(op.Type), SelBase: SelBase{Sels: op.Sels}}
+	plan := &Plan{op: op, exe: exe, top: c.field(&field, root.schema)}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return plan, nil
+}
+
+func (c *compiler) field(f *Field, t Type) *planField {
+	fc := *f
+	fc.ConType = t
+	pf := planField{field: &fc}
+	if errs := fc.sortArgs(); 0 < len(errs) {
+		Errors(errs).in(fc.key())
+		pf.fails = errs
+		return &pf
+	}
+	switch fc.Name {
+	case "__typename":
+		pf.meta = fc.Name
+		return &pf
+	case "__type":
+		pf.meta = fc.Name
+		pf.ft = c.root.GetType("__Type")
+		c.sets(&pf)
+		return &pf
+	case "__schema":
+		pf.meta = fc.Name
+		pf.ft = c.root.uuSchemaType
+		c.sets(&pf)
+		return &pf
+	}
+	fd := c.root.getFieldDef(t, fc.Name)
+	if fd == nil {
+		pf.fails = []error{resWarnp(&fc, "%s is not a field in %s", fc.Name, t.Name())}
+		return &pf
+	}
+	pf.fd = fd
+	pf.ft = fd.Type
+	if _, top := t.(*Schema); !top {
+		pf.hint = fieldCacheHint(fd, t == c.opType)
+	}
+	pf.hasArgs = 0 < len(fc.Args)
+	for _, av := range fc.Args {
+		if av != nil {
+			var at Type
+			if a := fd.getArg(av.Arg); a != nil {
+				at = a.Type
+			}
+			pf.args = append(pf.args, planArg{av: av, t: at})
+		}
+	}
+	for _, a := range fd.args.list {
+		if _, ok := a.Type.(*NonNull); ok {
+			if av := fc.getArg(a.N); av == nil || av.Value == nil {
+				pf.missing = append(pf.missing, a.N)
+			}
+		}
+	}
+	c.sets(&pf)
+	c.bindReflect(&pf, t)
+
+	return &pf
+}
+
+// sets compiles the selections of the field for each type a value of the
+// field type can be resolved as. That is the base type unless the base type
+// is a union in which case it is each of the union members.
+func (c *compiler) sets(pf *planField) {
+	sels := pf.field.Sels
+	switch bt := BaseType(pf.ft).(type) {
+	case *Object, *Schema, *Interface, *uuSchema:
+		pf.sels = c.set(sels, bt)
+	case *Union:
+		pf.members = make(map[Type]*planSet, len(bt.Members))
+		for _, m := range bt.Members {
+			if ot, _ := m.(*Object); ot != nil {
+				pf.members[ot] = c.set(sels, ot)
+			}
+		}
+	}
+}
+
+func (c *compiler) set(sels []Selection, t Type) *planSet {
+	set := planSet{t: t, leaf: len(sels) == 0, sels: make([]planSel, 0, len(sels)), flat: true}
+	keys := map[string]bool{}
+	for _, sel := range sels {
+		ps := planSel{sel: sel, dynamic: hasVarCondition(sel)}
+		if !ps.dynamic {
+			if skip, _ := c.root.skipSel(sel, nil); skip {
+				continue
+			}
+		}
+		switch ts := sel.(type) {
+		case *Field:
+			ps.field = c.field(ts, t)
+			ps.fields = []*planField{ps.field}
+			if keys[ts.key()] {
+				set.flat = false
+			}
+			keys[ts.key()] = true
+		case *Inline:
+			ps.frag = c.frag(nil, ts.Condition, ts.Sels, t)
+		case *FragRef:
+			if c.active[ts.Fragment] {
+				c.err = resError(ts.line, ts.col, "fragment %s is used recursively", ts.Fragment.Name)
+				continue
+			}
+			c.active[ts.Fragment] = true
+			ps.frag = c.frag(ts, ts.Fragment.Condition, ts.Fragment.Sels, t)
+			delete(c.active, ts.Fragment)
+		}
+		if ps.dynamic || ps.frag != nil {
+			set.flat = false
+		}
+		set.sels = append(set.sels, ps)
+	}
+	return &set
+}
+
+func (c *compiler) frag(ref *FragRef, cond Type, sels []Selection, t Type) *planFrag {
+	pf := planFrag{ref: ref}
+	if ref != nil {
+		pf.label = fmt.Sprintf("fragment at %d:%d", ref.Line(), ref.Column())
+	}
+	ci, _ := cond.(*Interface)
+	if cond == nil || cond == t || ci != nil && ci.isImplementedBy(t) {
+		pf.static = c.set(sels, t)
+		return &pf
+	}
+	switch t.(type) {
+	case *Interface, *Union:
+		pf.sets = map[*Object]*planSet{}
+		if co, _ := cond.(*Object); co != nil {
+			pf.sets[co] = c.set(sels, co)
+		} else if ci != nil {
+			for _, x := range c.root.types.list {
+				if ot, _ := x.(*Object); ot != nil && ci.isImplementedBy(ot) {
+					pf.sets[ot] = c.set(sels, ot)
+				}
+			}
+		}
+	}
+	return &pf
+}
+
+// bindReflect sets the reflection accessor for the field if the Go type of
+// the container object is already known.
+func (c *compiler) bindReflect(pf *planField, t Type) {
+	var ot *Object
+	switch tt := t.(type) {
+	case *Object:
+		ot = tt
+	case *Schema:
+		ot = &tt.Object
+	}
+	if ot == nil {
+		return
+	}
+	ot.mu.Lock()
+	meta := ot.meta
+	ot.mu.Unlock()
+	if meta == nil {
+		return
+	}
+	pf.fd.mu.Lock()
+	goIndex := pf.fd.goIndex
+	method := pf.fd.method
+	pf.fd.mu.Unlock()
+	if acc := newReflectAcc(meta, pf.fd, goIndex, method); acc != nil {
+		pf.acc.Store(acc)
+	}
+}
+
+// set returns the compiled selections for the type. A type not anticipated
+// when the field was compiled has the selections compiled as needed.
+func (pf *planField) set(root *Root, t Type) *planSet {
+	if pf.sels != nil && pf.sels.t == t {
+		return pf.sels
+	}
+	if set := pf.members[t]; set != nil {
+		return set
+	}
+	c := compiler{root: root, active: map[*Fragment]bool{}}
+
+	return c.set(pf.field.Sels, t)
+}
+
+// newReflectAcc returns an accessor for the struct field at the index found
+// when the field was registered or for the method if there is no index.
+func newReflectAcc(meta reflect.Type, fd *FieldDef, goIndex []int, method *reflect.Value) *reflectAcc {
+	acc := reflectAcc{meta: meta, fd: fd}
+	switch {
+	case 0 < len(goIndex):
+		acc.index = goIndex
+	case method != nil:
+		acc.method = method
+	default:
+		return nil
+	}
+	return &acc
+}
+
+// hasVarCondition returns true if the selection has a @skip or @include
+// directive with a variable as the condition.
+func hasVarCondition(sel Selection) bool {
+	for _, du := range sel.Directives() {
+		switch du.Directive.Name() {
+		case "skip", "include":
+			if av := du.Args["if"]; av != nil {
+				if _, ok := av.Value.(Var); ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// freshErrors returns copies of errors determined when compiling so that
+// adding to the path of an error does not change the original.
+func freshErrors(errs []error) []error {
+	ea := make([]error, len(errs))
+	for i, e := range errs {
+		if ge, ok := e.(*Error); ok { //nolint:errorlint
+			ce := *ge
+			ea[i] = &ce
+		} else {
+			ea[i] = e
+		}
+	}
+	return ea
+}
@@ -0,0 +1,126 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+func TestPlanResolve(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	src := `query find($name: String!, $songs: Boolean!) {
+  artist(name: $name) {
+    name
+    songs @include(if: $songs) {name}
+    origin @skip(if: true)
+  }
+}`
+	exe, err := root.ParseExecutableString(src)
+	checkNil(t, err, "parse failed. %s", err)
+	before := exe.String()
+
+	plan, err := root.Compile(exe, "find")
+	checkNil(t, err, "compile failed. %s", err)
+
+	for _, v := range []struct {
+		vars   map[string]interface{}
+		expect string
+	}{
+		{
+			vars:   map[string]interface{}{"name": "Fazerdaze", "songs": false},
+			expect: `{"data":{"artist":{"name":"Fazerdaze"}}}`,
+		},
+		{
+			vars: map[string]interface{}{"name": "Viagra Boys", "songs": true},
+			expect: `{"data":{"artist":{"name":"Viagra Boys","songs":[{"name":"Down In The Basement"},` +
+				`{"name":"Frogstrap"},{"name":"Worms"},{"name":"Amphetanarchy"}]}}}`,
+		},
+	} {
+		result, err := root.ResolvePlan(plan, v.vars)
+		checkNil(t, err, "resolve plan failed. %s", err)
+		var b strings.Builder
+		_ = ggql.WriteJSONValue(&b, result, -1)
+		checkEqual(t, v.expect, b.String(), "result mismatch for %v", v.vars)
+	}
+	checkEqual(t, before, exe.String(), "executable should not change")
+	f, _ := exe.Ops["find"].Sels[0].(*ggql.Field)
+	checkNotNil(t, f, "first selection should be a field")
+	checkEqual(t, true, f.ConType == nil, "executable field ConType should not be set")
+}
+
+func TestPlanResolveConcurrent(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	exe, err := root.ParseExecutableString(`{artists{name songs{name duration}}}`)
+	checkNil(t, err, "parse failed. %s", err)
+	plan, err := root.Compile(exe, "")
+	checkNil(t, err, "compile failed. %s", err)
+
+	result, _ := root.ResolvePlan(plan, nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	expect := b.String()
+
+	var wg sync.WaitGroup
+	results := make([]string, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, _ := root.ResolvePlan(plan, nil)
+			var b strings.Builder
+			_ = ggql.WriteJSONValue(&b, result, -1)
+			results[i] = b.String()
+		}(i)
+	}
+	wg.Wait()
+	for _, r := range results {
+		checkEqual(t, expect, r, "concurrent result mismatch")
+	}
+}
+
+func TestPlanCompileErrors(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	exe, err := root.ParseExecutableString(`
+query a {title}
+query b {title}
+`)
+	checkNil(t, err, "parse failed. %s", err)
+	_, err = root.Compile(exe, "")
+	checkNotNil(t, err, "compile without an operation name should fail")
+
+	exe, err = root.ParseExecutableString(`
+{artists{...A}}
+fragment A on Artist {name songs{artist{...A}}}
+`)
+	checkNil(t, err, "parse failed. %s", err)
+	_, err = root.Compile(exe, "")
+	checkNotNil(t, err, "compile of a recursive fragment should fail")
+	checkEqual(t, "resolve error: fragment A is used recursively from 3:46", err.Error(), "recursive fragment error")
+}
+
+func TestPlanMergeFields(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	src := `{artist(name: "Fazerdaze") {songs {name} ... on Artist {songs {duration}}}}`
+	result := root.ResolveString(src, "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, `{"data":{"artist":{"songs":[{"duration":240,"name":"Jennifer"},{"duration":170,"name":"Lucky Girl"},`+
+		`{"duration":194,"name":"Friends"},{"duration":193,"name":"Reel"}]}}}`,
+		b.String(), "fields with the same response name should be merged")
+}
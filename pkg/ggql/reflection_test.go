@@ -197,6 +197,26 @@ func TestReflectInlineUnion(t *testing.T) {
 	testReflect(t, "", src, expect, pre)
 }
 
+func TestReflectUnionTypename(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	err := root.RegisterType(&RArtist{}, "Artist")
+	checkNil(t, err, "RegisterType should not fail. %s", err)
+	err = root.RegisterType(&RSong{}, "Song")
+	checkNil(t, err, "RegisterType should not fail. %s", err)
+
+	src := `{all{__typename ...on Artist {t: __typename name} ...on Song {t: __typename}}}`
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, root.ResolveString(src, "", nil), -1)
+	checkEqual(t, `{"data":{"all":[`+
+		`{"__typename":"Artist","name":"Fazerdaze","t":"Artist"},`+
+		`{"__typename":"Song","t":"Song"},{"__typename":"Song","t":"Song"},`+
+		`{"__typename":"Song","t":"Song"},{"__typename":"Song","t":"Song"},`+
+		`{"__typename":"Artist","name":"Viagra Boys","t":"Artist"},`+
+		`{"__typename":"Song","t":"Song"},{"__typename":"Song","t":"Song"},`+
+		`{"__typename":"Song","t":"Song"},{"__typename":"Song","t":"Song"}]}}`,
+		b.String(), "result mismatch for %s", src)
+}
+
 func TestReflectInterface(t *testing.T) {
 	src := `{named{name}}`
 	sdl := `
@@ -251,6 +271,37 @@ extend type Song implements Namely {}
 	testReflect(t, sdl, src, expect, pre)
 }
 
+func TestReflectInterfaceLateRegister(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	err := root.ParseString(`
+interface Namely {name: String!}
+extend type Query {named: [Namely]}
+extend type Artist implements Namely {}
+extend type Song implements Namely {}
+`)
+	checkNil(t, err, "extend should not fail. %s", err)
+
+	src := `{named{name}}`
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, root.ResolveString(src, "", nil), -1)
+	checkEqual(t, `{"data":{"named":[`+strings.Repeat(`{"name":null},`, 9)+`{"name":null}]}}`,
+		b.String(), "result mismatch for %s", src)
+
+	// The Go types did not match before so registering must replace the
+	// cached misses.
+	err = root.RegisterType(&RArtist{}, "Artist")
+	checkNil(t, err, "RegisterType should not fail. %s", err)
+	err = root.RegisterType(&RSong{}, "Song")
+	checkNil(t, err, "RegisterType should not fail. %s", err)
+
+	b.Reset()
+	_ = ggql.WriteJSONValue(&b, root.ResolveString(src, "", nil), -1)
+	checkEqual(t, `{"data":{"named":[{"name":"Fazerdaze"},{"name":"Jennifer"},{"name":"Lucky Girl"},`+
+		`{"name":"Friends"},{"name":"Reel"},{"name":"Viagra Boys"},{"name":"Down In The Basement"},`+
+		`{"name":"Frogstrap"},{"name":"Worms"},{"name":"Amphetanarchy"}]}}`,
+		b.String(), "result mismatch for %s", src)
+}
+
 func TestReflectArgOrder(t *testing.T) {
 	src := `{song(song: "Jennifer", artist: "Fazerdaze"){name}}`
 	expect := `{
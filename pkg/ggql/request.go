@@ -0,0 +1,139 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Request is a GraphQL request as commonly sent as JSON over HTTP.
+type Request struct {
+
+	// Query is the executable document. It can be empty if a persisted
+	// query hash is provided in the extensions.
+	Query string `json:"query"`
+
+	// OperationName is the name of the operation to evaluate.
+	OperationName string `json:"operationName"`
+
+	// Variables are the variable values for the operation.
+	Variables map[string]interface{} `json:"variables"`
+
+	// Extensions to the request. The persistedQuery extension of the
+	// automatic persisted query protocol is supported.
+	Extensions map[string]interface{} `json:"extensions"`
+
+	// Options if not nil replace the options of the root when evaluating
+	// the request.
+	Options *Options `json:"-"`
+
+	// Session identifies the client making the request, such as a user or
+	// session ID. Responses with a private cache policy are only cached in
+	// the ResponseCache of the root for requests with a Session.
+	Session string `json:"-"`
+}
+
+// ResolveRequest evaluates a request. If the root has a PersistedQueries
+// store the automatic persisted query protocol is followed. A request with
+// a persistedQuery extension and no query is evaluated with the query from
+// the store or, if the hash is not in the store, a PersistedQueryNotFound
+// error is returned so the client can send the query along with the hash.
+// A query with a hash is added to the store unless PersistedOnly is set in
+// which case only queries already in the store are evaluated.
+func (root *Root) ResolveRequest(req *Request) map[string]interface{} {
+	result, _ := root.ResolveRequestPolicy(req)
+
+	return result
+}
+
+// ResolveRequestPolicy evaluates a request in the same way as
+// ResolveRequest and also returns the cache policy of the response. The
+// Header() of the policy is the value for an HTTP Cache-Control header. If
+// the root has a ResponseCache the response is taken from the cache when
+// possible and cached according to the policy otherwise.
+func (root *Root) ResolveRequestPolicy(req *Request) (map[string]interface{}, CachePolicy) {
+	query, err := root.persistedQuery(req)
+	if err != nil {
+		result := map[string]interface{}{}
+		if errors := root.FormErrors(err); 0 < len(errors) {
+			result["errors"] = errors
+		}
+		return result, CachePolicy{}
+	}
+	return root.resolveReader(strings.NewReader(query), req.OperationName, req.Variables, req.Options, req.Session)
+}
+
+func (root *Root) persistedQuery(req *Request) (string, error) {
+	store := root.PersistedQueries
+	if store == nil {
+		return req.Query, nil
+	}
+	pq, _ := req.Extensions["persistedQuery"].(map[string]interface{})
+	if pq == nil {
+		if root.PersistedOnly {
+			if _, err := store.Get(QueryHash(req.Query)); err != nil {
+				return "", pqError(CodePersistedQueryNotAllowed, "query is not in the persisted query store")
+			}
+		}
+		return req.Query, nil
+	}
+	if !isVersion1(pq["version"]) {
+		return "", pqError(CodePersistedQueryNotSupported, "persisted query version %v is not supported", pq["version"])
+	}
+	hash, _ := pq["sha256Hash"].(string)
+	if len(req.Query) == 0 {
+		query, err := store.Get(hash)
+		if err != nil {
+			return "", &Error{
+				Base:       ErrPersistedQueryNotFound,
+				Extensions: map[string]interface{}{"code": CodePersistedQueryNotFound},
+			}
+		}
+		return query, nil
+	}
+	if QueryHash(req.Query) != hash {
+		return "", pqError(CodeInvalidPersistedQueryHash, "provided sha256Hash does not match the query")
+	}
+	if _, err := store.Get(hash); err != nil {
+		if root.PersistedOnly {
+			return "", pqError(CodePersistedQueryNotAllowed, "query is not in the persisted query store")
+		}
+		// A failure to store the query is not returned as the client will
+		// send the query again if it is not found next time.
+		_ = store.Put(hash, req.Query)
+	}
+	return req.Query, nil
+}
+
+func pqError(code string, format string, args ...interface{}) error {
+	return &Error{
+		Base:       fmt.Errorf("%w: "+format, append([]interface{}{ErrPersistedQuery}, args...)...),
+		Extensions: map[string]interface{}{"code": code},
+	}
+}
+
+// isVersion1 returns true if v is 1 as decoded from JSON or set from Go.
+func isVersion1(v interface{}) bool {
+	switch tv := v.(type) {
+	case float64:
+		return tv == 1
+	case int:
+		return tv == 1
+	case int64:
+		return tv == 1
+	}
+	return false
+}
@@ -19,14 +19,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"reflect"
+	"runtime/debug"
+	"sort"
 	"strings"
 	"time"
 )
 
 // MaxResolveDepth is the maximum depth to allow during resolving. This will
 // cause recursively nested queries to abort when the maximum depth is
-// reached.
+// reached. It is the default for the MaxResolveDepth option of a Root.
 var MaxResolveDepth = 100
 
 // ResolveBytes parses an SDL executable []byte and then evaluates it.
@@ -39,49 +42,189 @@ func (root *Root) ResolveString(src string, op string, vars map[string]interface
 	return root.ResolveReader(strings.NewReader(src), op, vars)
 }
 
-// ResolveReader parses an SDL reader and then evaluates it.
+// ResolveReader parses an SDL reader and then evaluates it. If the
+// ExecutableCacheSize of the root is greater than zero then the compiled
+// plans of previously parsed documents are taken from the cache instead of
+// parsing and compiling the document again.
 func (root *Root) ResolveReader(r io.Reader, op string, vars map[string]interface{}) map[string]interface{} {
-	var result map[string]interface{}
-	exe, err := root.ParseExecutableReader(r)
+	result, _ := root.resolveReader(r, op, vars, nil, "")
+
+	return result
+}
+
+// resolveReader parses, compiles, and evaluates a document or takes the
+// response from the ResponseCache of the root if there is one. The session
+// identifies the client for private responses. The cache policy of a
+// response with errors is always zero.
+func (root *Root) resolveReader(
+	r io.Reader,
+	op string,
+	vars map[string]interface{},
+	opts *Options,
+	session string) (result map[string]interface{}, policy CachePolicy) {
+
+	plan, parsed, err := root.cachedPlan(r, op)
 	if err == nil {
-		if result, err = root.ResolveExecutable(exe, op, vars); result == nil {
-			result = map[string]interface{}{"data": nil}
+		ro := root.requestOptions(opts)
+		var key string
+		if result, policy, key = root.cachedResponse(plan, vars, &ro, session); result != nil {
+			return
+		}
+		if result, policy, err = root.resolvePlan(plan, vars, &ro); err == nil {
+			root.cacheResponse(key, session, result, policy)
 		}
 	}
+	if parsed && result == nil {
+		result = map[string]interface{}{"data": nil}
+	}
+	if result == nil {
+		result = map[string]interface{}{}
+	}
 	if err != nil {
-		errors := FormErrorsResult(err)
-		if result == nil {
-			result = map[string]interface{}{"errors": errors}
+		policy = CachePolicy{}
+		if errors := root.FormErrors(err); 0 < len(errors) {
+			result["errors"] = errors
 		}
-		result["errors"] = errors
 	}
-	return result
+	return
+}
+
+// cachedPlan returns a compiled plan for the operation either from the
+// executable cache or by parsing and compiling the reader and adding the
+// result to the cache. The parsed return value indicates the document was
+// parsed and validated even if the plan could not be compiled.
+func (root *Root) cachedPlan(r io.Reader, op string) (plan *Plan, parsed bool, err error) {
+	var exe *Executable
+	max := root.ExecutableCacheSize
+	if max <= 0 {
+		if exe, err = root.ParseExecutableReader(r); err == nil {
+			parsed = true
+			plan, err = root.Compile(exe, op)
+		}
+		return
+	}
+	var src []byte
+	if src, err = ioutil.ReadAll(r); err != nil {
+		return nil, false, fmt.Errorf("%w: %s", ErrParse, err)
+	}
+	root.init()
+	types := root.types
+	key := QueryHash(string(src)) + " " + op
+	if plan = root.exeCache.get(types, key); plan != nil {
+		return plan, true, nil
+	}
+	if exe, err = root.ParseExecutable(src); err == nil {
+		parsed = true
+		if plan, err = root.Compile(exe, op); err == nil {
+			root.exeCache.put(types, key, plan, max)
+		}
+	}
+	return
 }
 
-// ResolveExecutable resolves an Executable.
+// ResolveExecutable resolves an Executable. The operation is compiled into
+// a Plan which is then evaluated. Use Compile() and ResolvePlan() to avoid
+// compiling again when evaluating the same operation more than once.
 func (root *Root) ResolveExecutable(
 	exe *Executable,
 	opName string,
 	vars map[string]interface{}) (result map[string]interface{}, err error) {
 
+	var plan *Plan
+	if plan, err = root.Compile(exe, opName); err != nil {
+		return nil, err
+	}
+	return root.ResolvePlan(plan, vars)
+}
+
+// ResolvePlan evaluates a compiled Plan with the variables provided. A Plan
+// can be evaluated any number of times including concurrently.
+func (root *Root) ResolvePlan(plan *Plan, vars map[string]interface{}) (result map[string]interface{}, err error) {
+	return root.ResolvePlanOptions(plan, vars, nil)
+}
+
+// ResolvePlanOptions evaluates a compiled Plan in the same way as
+// ResolvePlan but with the options provided instead of the options of the
+// root. If opts is nil the options of the root are used.
+func (root *Root) ResolvePlanOptions(
+	plan *Plan,
+	vars map[string]interface{},
+	opts *Options) (result map[string]interface{}, err error) {
+
+	result, _, err = root.resolvePlan(plan, vars, opts)
+
+	return
+}
+
+// resolvePlan evaluates a plan and returns the cache policy of the result
+// along with the result.
+func (root *Root) resolvePlan(
+	plan *Plan,
+	vars map[string]interface{},
+	opts *Options) (result map[string]interface{}, policy CachePolicy, err error) {
+
 	// Returned error can be either an array of errors as a Errors, an Error,
 	// or just a plain fmt.Errorf() return.
 
-	op := exe.Ops[opName]
-	if op == nil {
-		if len(exe.Ops) == 1 {
-			for _, o := range exe.Ops {
-				op = o
-				break
+	op := plan.op
+	ex := root.newExecution(nil, opts)
+	if ex.vars, err = root.coerceVars(op, vars, ex.opts.Relaxed); err != nil {
+		return
+	}
+	result = map[string]interface{}{}
+	if root.DeprecationWarnings {
+		if warns := root.deprecationWarnings(op, vars); 0 < len(warns) {
+			result["extensions"] = map[string]interface{}{"warnings": FormErrorsResult(Errors(warns))}
+		}
+	}
+	if op.Type == OpSubscription {
+		var ea []error
+		var data interface{}
+		if data, _, ea = root.resolveField(root.obj, ex, []*planField{plan.top}, 1); len(ea) == 0 {
+			found := false
+			var vals []interface{}
+			switch td := data.(type) {
+			case map[string]interface{}:
+				for _, val := range td {
+					vals = append(vals, val)
+				}
+			case *OrderedMap:
+				vals = td.Values
+			}
+			for _, val := range vals {
+				if sub, _ := val.(*Subscription); sub != nil {
+					root.subscribe(sub)
+					found = true
+				}
+			}
+			if !found {
+				ea = append(ea, resError(op.line, op.col, "no subscriptions resolved"))
 			}
 		}
-		if op == nil {
-			return nil, fmt.Errorf("%w, could not determine operation to evaluate", ErrResolve)
+		if 0 < len(ea) {
+			err = Errors(ea)
 		}
+		return nil, policy, err
 	}
-	field := Field{Alias: "data", Name: string(op.Type), SelBase: SelBase{Sels: op.Sels}}
+	data, ok, ea := root.resolveField(root.obj, ex, []*planField{plan.top}, ex.maxDepth)
+	if ok {
+		result["data"] = data
+	}
+	if 0 < len(ea) {
+		err = Errors(ea)
+	}
+	policy = ex.cachePolicy(op)
+
+	return
+}
+
+// coerceVars returns the variable values for an operation with defaults
+// applied and values coerced to the variable types.
+func (root *Root) coerceVars(
+	op *Op,
+	vars map[string]interface{},
+	relaxed bool) (opVars map[string]interface{}, err error) {
 
-	var opVars map[string]interface{}
 	if 0 < len(op.Variables) {
 		opVars = map[string]interface{}{}
 		for _, vd := range op.Variables {
@@ -89,7 +232,7 @@ func (root *Root) ResolveExecutable(
 			if vars != nil {
 				if v := vars[vd.Name]; v != nil {
 					if ic, _ := vd.Type.(InCoercer); ic != nil { // validated in SDL validation
-						v, err = ic.CoerceIn(v)
+						v, err = coerceIn(ic, v, relaxed)
 					}
 					if err != nil {
 						var gerr *Error
@@ -99,6 +242,7 @@ func (root *Root) ResolveExecutable(
 						} else {
 							err = resError(vd.line, vd.col, "%s for %s", err, vd.Name)
 						}
+						err = inputError(err)
 						return
 					}
 					opVars[vd.Name] = v
@@ -106,37 +250,13 @@ func (root *Root) ResolveExecutable(
 			}
 		}
 	}
-	result = map[string]interface{}{}
-	if op.Type == OpSubscription {
-		var ea []error
-		if ea = root.resolveField(root.obj, opVars, &field, root.schema, result, 1); len(ea) == 0 {
-			found := false
-			subMap, _ := result["data"].(map[string]interface{})
-			for _, val := range subMap {
-				if sub, _ := val.(*Subscription); sub != nil {
-					root.subscribe(sub)
-					found = true
-				}
-			}
-			if !found {
-				ea = append(ea, resError(op.line, op.col, "no subscriptions resolved"))
-			}
-		}
-		if 0 < len(ea) {
-			err = Errors(ea)
-		}
-		return nil, err
-	}
-	if ea := root.resolveField(root.obj, opVars, &field, root.schema, result, MaxResolveDepth); 0 < len(ea) {
-		err = Errors(ea)
-	}
 	return
 }
 
 func (root *Root) resolve(
 	obj interface{},
-	vars map[string]interface{},
-	field *Field,
+	ex *execution,
+	pfs []*planField,
 	t Type,
 	depth int) (result interface{}, ea []error) {
 
@@ -147,29 +267,20 @@ func (root *Root) resolve(
 	}
 	switch tt := t.(type) {
 	case *List:
-		result, ea = root.resolveList(obj, vars, field, tt, depth-1)
+		result, ea = root.resolveList(obj, ex, pfs, tt, depth-1)
 	case *Object, *Schema, *Interface, *uuSchema:
-		result, ea = root.resolveFieldSels(obj, vars, field, t, depth-1)
+		result, ea = root.resolveObject(obj, ex, pfs, t, depth-1)
 	case *NonNull:
-		result, ea = root.resolve(obj, vars, field, tt.Base, depth)
+		result, ea = root.resolve(obj, ex, pfs, tt.Base, depth)
 	case *Union:
-		resMap := map[string]interface{}{}
-		result = resMap
-		// Use reflection to get the type meta for the object then walk
-		// through all the members of the union looking for a match. The
-		// object may have its meta field already set but the first time it
-		// will be nil so check for a @go directive then a type argument that
-		// matches the object type. If there is a match then set the meta.
-		objType := reflect.TypeOf(obj)
-		for _, m := range tt.Members {
-			if ot, _ := m.(*Object); ot != nil { // already checked in validation
-				if meta, err := ot.metaCheck(objType); err != nil {
-					return nil, []error{err}
-				} else if objType == meta {
-					result, ea = root.resolveFieldSels(obj, vars, field, m, depth-1)
-					break
-				}
-			}
+		m, err := root.unionMember(obj, tt)
+		if err != nil {
+			return nil, []error{err}
+		}
+		if m != nil {
+			result, ea = root.resolveObject(obj, ex, pfs, m, depth-1)
+		} else {
+			result = ex.newObjectResult(0).value()
 		}
 	default:
 		// Validation makes sure all output types are valid so no need to
@@ -178,55 +289,181 @@ func (root *Root) resolve(
 		if co, _ := tt.(OutCoercer); co != nil {
 			var err error
 			if result, err = co.CoerceOut(obj); err != nil {
-				ea = append(ea, resWarn(field.line, field.col, "%s", err))
+				ea = append(ea, resWarn(pfs[0].field.line, pfs[0].field.col, "%s", err))
 			}
 		}
 	}
 	return
 }
 
-func (root *Root) resolveFieldSels(
+// unionMember returns the member of the union that obj is a value of or nil
+// if obj is not a value of any of the members.
+func (root *Root) unionMember(obj interface{}, u *Union) (Type, error) {
+	// Some values such as generated mock values know their type.
+	if tv, ok := obj.(typedValue); ok {
+		return tv.objectType(), nil
+	}
+	// Use reflection to get the type meta for the object then walk through
+	// all the members of the union looking for a match. The object may have
+	// its meta field already set but the first time it will be nil so check
+	// for a @go directive then a type argument that matches the object
+	// type. If there is a match then set the meta.
+	objType := reflect.TypeOf(obj)
+	for _, m := range u.Members {
+		if ot, _ := m.(*Object); ot != nil { // already checked in validation
+			if meta, err := ot.metaCheck(objType); err != nil {
+				return nil, err
+			} else if objType == meta {
+				return m, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// resolveObject resolves the merged selections of pfs on obj.
+func (root *Root) resolveObject(
 	obj interface{},
-	vars map[string]interface{},
-	field *Field,
+	ex *execution,
+	pfs []*planField,
 	t Type,
 	depth int) (result interface{}, ea []error) {
 
-	mr := map[string]interface{}{}
-	ea = root.resolveSels(obj, vars, field.Sels, t, mr, depth)
-	result = mr
+	set := pfs[0].set(root, t)
+	if set.leaf {
+		return ex.newObjectResult(0).value(),
+			[]error{resWarnp(nil, "%s is not a valid output leaf type", set.t.Name())}
+	}
+	if len(pfs) == 1 && set.flat {
+		// No fragments, conditions, or duplicate response names so the
+		// fields can be resolved directly.
+		or := ex.newObjectResult(len(set.sels))
+		for i := range set.sels {
+			fv, ok, ea2 := root.resolveField(obj, ex, set.sels[i].fields, depth)
+			if ok {
+				or.add(set.sels[i].field.field.key(), fv)
+			}
+			ea = append(ea, ea2...)
+		}
+		return or.value(), ea
+	}
+	var fields []memberFields
+	index := map[string]int{}
+	for _, pf := range pfs {
+		fields, ea = root.collectFields(obj, ex, pf.set(root, t), nil, index, fields, ea)
+	}
+	or := ex.newObjectResult(len(fields))
+	for _, mf := range fields {
+		fv, ok, ea2 := root.resolveField(obj, ex, mf.fields, depth)
+		if ok {
+			or.add(mf.fields[0].field.key(), fv)
+		}
+		for i := len(mf.frags) - 1; 0 <= i; i-- {
+			Errors(ea2).in(mf.frags[i])
+		}
+		ea = append(ea, ea2...)
+	}
+	return or.value(), ea
+}
 
-	return
+// objectResult is the result of resolving an object, either a map or an
+// OrderedMap if the options are for ordered or sorted results.
+type objectResult struct {
+	m    map[string]interface{}
+	om   *OrderedMap
+	sort bool
 }
 
-func (root *Root) resolveSels(
-	obj interface{},
-	vars map[string]interface{},
-	sels []Selection,
-	t Type,
-	result map[string]interface{},
-	depth int) (ea []error) {
+func (ex *execution) newObjectResult(size int) objectResult {
+	if ex.opts.Ordered || ex.opts.Sort {
+		return objectResult{
+			om:   &OrderedMap{Keys: make([]string, 0, size), Values: make([]interface{}, 0, size)},
+			sort: ex.opts.Sort,
+		}
+	}
+	return objectResult{m: make(map[string]interface{}, size)}
+}
 
-	if len(sels) == 0 {
-		return []error{resWarnp(nil, "%s is not a valid output leaf type", t.Name())}
+func (or objectResult) add(key string, value interface{}) {
+	if or.om != nil {
+		or.om.Keys = append(or.om.Keys, key)
+		or.om.Values = append(or.om.Values, value)
+	} else {
+		or.m[key] = value
 	}
-	for _, sel := range sels {
-		skip, ea2 := root.skipSel(sel, vars)
-		ea = append(ea, ea2...)
-		if skip {
+}
+
+func (or objectResult) value() interface{} {
+	if or.om != nil {
+		if or.sort {
+			sort.Sort(byKey{or.om})
+		}
+		return or.om
+	}
+	return or.m
+}
+
+// memberFields are all the fields with the same response name along with
+// the labels of the fragments the first of the fields was in.
+type memberFields struct {
+	fields []*planField
+	frags  []string
+}
+
+// collectFields adds the fields that apply to obj from set to fields
+// keeping the order of the first occurrence of each response name. Fields
+// with a response name already in fields are merged with the earlier
+// fields.
+func (root *Root) collectFields(
+	obj interface{},
+	ex *execution,
+	set *planSet,
+	frags []string,
+	index map[string]int,
+	fields []memberFields,
+	ea []error) ([]memberFields, []error) {
+
+	for i := range set.sels {
+		ps := &set.sels[i]
+		if ps.dynamic {
+			skip, ea2 := root.skipSel(ps.sel, ex.vars)
+			for j := len(frags) - 1; 0 <= j; j-- {
+				Errors(ea2).in(frags[j])
+			}
+			ea = append(ea, ea2...)
+			if skip {
+				continue
+			}
+		}
+		if ps.field != nil {
+			key := ps.field.field.key()
+			if j, has := index[key]; has {
+				fields[j].fields = append(fields[j].fields, ps.field)
+				continue
+			}
+			index[key] = len(fields)
+			fields = append(fields, memberFields{fields: ps.fields, frags: frags})
 			continue
 		}
-		switch ts := sel.(type) {
-		case *Inline:
-			ea2 = root.resolveInline(obj, vars, ts, t, result, depth)
-		case *FragRef:
-			ea2 = root.resolveFragRef(obj, vars, ts, t, result, depth)
-		case *Field:
-			ea2 = root.resolveField(obj, vars, ts, t, result, depth)
+		fs := root.fragSet(obj, ps.frag)
+		if fs == nil {
+			continue
 		}
-		ea = append(ea, ea2...)
+		f2 := frags
+		if ps.frag.ref != nil {
+			f2 = append(frags[:len(frags):len(frags)], ps.frag.label)
+		}
+		if fs.leaf {
+			e := resWarnp(nil, "%s is not a valid output leaf type", fs.t.Name())
+			for j := len(f2) - 1; 0 <= j; j-- {
+				Errors{e}.in(f2[j])
+			}
+			ea = append(ea, e)
+			continue
+		}
+		fields, ea = root.collectFields(obj, ex, fs, f2, index, fields, ea)
 	}
-	return
+	return fields, ea
 }
 
 func (root *Root) skipSel(sel Selection, vars map[string]interface{}) (skip bool, ea []error) {
@@ -244,7 +481,7 @@ func (root *Root) skipSel(sel Selection, vars map[string]interface{}) (skip bool
 						skip = b
 					} else {
 						skip = true // default to skipping
-						ea = append(ea, resWarnp(sel, "%v is not a valid 'if' value for @skip", v))
+						ea = append(ea, inputError(resWarnp(sel, "%v is not a valid 'if' value for @skip", v)))
 					}
 				}
 			}
@@ -260,7 +497,7 @@ func (root *Root) skipSel(sel Selection, vars map[string]interface{}) (skip bool
 						skip = !b
 					} else {
 						skip = true // default to skipping
-						ea = append(ea, resWarnp(sel, "%v is not a valid 'if' value for @include", v))
+						ea = append(ea, inputError(resWarnp(sel, "%v is not a valid 'if' value for @include", v)))
 					}
 				}
 			}
@@ -271,192 +508,152 @@ func (root *Root) skipSel(sel Selection, vars map[string]interface{}) (skip bool
 
 func (root *Root) resolveList(
 	obj interface{},
-	vars map[string]interface{},
-	field *Field,
+	ex *execution,
+	pfs []*planField,
 	t *List,
 	depth int) (result interface{}, ea []error) {
 
-	var ea2 []error
+	var rlist []interface{}
+	ea, ok := root.listEach(obj, func(i int, v interface{}, raw bool) (ea2 []error) {
+		if !raw {
+			v, ea2 = root.resolve(v, ex, pfs, t.Base, depth)
+			Errors(ea2).in(i)
+		}
+		rlist = append(rlist, v)
+		return
+	})
+	if !ok {
+		return nil, append(ea, resWarn(pfs[0].field.line, pfs[0].field.col, "%T is not a list type", obj))
+	}
+	if rlist == nil {
+		rlist = []interface{}{}
+	}
+	return rlist, ea
+}
 
-	lt := t.Base
+// listEach calls fn for each member of a list value. The raw argument to fn
+// is true if the member should be used as is such as the members of a Go
+// slice of scalars. False is returned if obj is not a list.
+func (root *Root) listEach(obj interface{}, fn func(i int, v interface{}, raw bool) []error) (ea []error, ok bool) {
+	ok = true
 	switch list := obj.(type) {
 	case ListResolver:
-		var rlist []interface{}
 		cnt := list.Len()
-		var v interface{}
 		for i := 0; i < cnt; i++ {
-			v, ea2 = root.resolve(list.Nth(i), vars, field, lt, depth)
-			Errors(ea2).in(i)
-			ea = append(ea, ea2...)
-			rlist = append(rlist, v)
+			ea = append(ea, fn(i, list.Nth(i), false)...)
 		}
-		result = rlist
 	case []interface{}:
-		rlist := make([]interface{}, 0, len(list))
-		var v interface{}
 		for i, x := range list {
-			v, ea2 = root.resolve(x, vars, field, lt, depth)
-			Errors(ea2).in(i)
-			ea = append(ea, ea2...)
-			rlist = append(rlist, v)
+			ea = append(ea, fn(i, x, false)...)
 		}
-		result = rlist
 	case []string:
-		rlist := make([]interface{}, 0, len(list))
-		for _, s := range list {
-			rlist = append(rlist, s)
+		for i, s := range list {
+			ea = append(ea, fn(i, s, true)...)
 		}
-		result = rlist
 	case []int:
-		rlist := make([]interface{}, 0, len(list))
-		for _, i := range list {
-			rlist = append(rlist, i)
+		for i, x := range list {
+			ea = append(ea, fn(i, x, true)...)
 		}
-		result = rlist
 	case []int64:
-		rlist := make([]interface{}, 0, len(list))
-		for _, i := range list {
-			rlist = append(rlist, i)
+		for i, x := range list {
+			ea = append(ea, fn(i, x, true)...)
 		}
-		result = rlist
 	case []bool:
-		rlist := make([]interface{}, 0, len(list))
-		for _, b := range list {
-			rlist = append(rlist, b)
+		for i, b := range list {
+			ea = append(ea, fn(i, b, true)...)
 		}
-		result = rlist
 	case []float32:
-		rlist := make([]interface{}, 0, len(list))
-		for _, f := range list {
-			rlist = append(rlist, f)
+		for i, f := range list {
+			ea = append(ea, fn(i, f, true)...)
 		}
-		result = rlist
 	case []float64:
-		rlist := make([]interface{}, 0, len(list))
-		for _, f := range list {
-			rlist = append(rlist, f)
+		for i, f := range list {
+			ea = append(ea, fn(i, f, true)...)
 		}
-		result = rlist
 	case []time.Time:
-		rlist := make([]interface{}, 0, len(list))
-		for _, f := range list {
-			rlist = append(rlist, f)
+		for i, f := range list {
+			ea = append(ea, fn(i, f, true)...)
 		}
-		result = rlist
 	default:
 		if root.AnyResolver != nil {
-			var rlist []interface{}
 			cnt := root.AnyResolver.Len(obj)
 			for i := 0; i < cnt; i++ {
 				v, err := root.AnyResolver.Nth(obj, i)
-				if err == nil {
-					v, ea2 = root.resolve(v, vars, field, lt, depth)
-					Errors(ea2).in(i)
-					ea = append(ea, ea2...)
-				} else {
+				if err != nil {
 					e := resWarnp(nil, "%s", err)
 					var ge *Error
 					if errors.As(e, &ge) {
 						ge.in(i)
 					}
 					ea = append(ea, e)
+					ea = append(ea, fn(i, v, true)...)
+					continue
 				}
-				rlist = append(rlist, v)
+				ea = append(ea, fn(i, v, false)...)
 			}
-			result = rlist
 		} else {
 			rv := reflect.ValueOf(obj)
 			switch rv.Kind() {
 			case reflect.Slice, reflect.Array:
-				var rlist []interface{}
 				cnt := rv.Len()
 				for i := 0; i < cnt; i++ {
-					v := rv.Index(i).Interface()
-					v, ea2 = root.resolve(v, vars, field, lt, depth)
-					rlist = append(rlist, v)
-					Errors(ea2).in(i)
-					ea = append(ea, ea2...)
+					ea = append(ea, fn(i, rv.Index(i).Interface(), false)...)
 				}
-				result = rlist
 			default:
-				ea = append(ea, resWarn(field.line, field.col, "%T is not a list type", obj))
+				ok = false
 			}
 		}
 	}
 	return
 }
 
-func (root *Root) formArgs(
-	vars map[string]interface{},
-	field *Field,
-	fd *FieldDef) (args map[string]interface{}, ea []error) {
-
-	required := map[string]bool{}
-	if fd != nil {
-		if fd.args.dict != nil {
-			for k, a := range fd.args.dict {
-				if _, ok := a.Type.(*NonNull); ok {
-					required[k] = false
-				}
-			}
-		}
-	}
+func (root *Root) formArgs(ex *execution, pf *planField) (args map[string]interface{}, ea []error) {
 	// Build the args by combining provided args and variable values as
 	// appropriate.
-	if 0 < len(field.Args) {
-		args = map[string]interface{}{}
-		for _, av := range field.Args {
-			if av != nil {
-				var at Type
-				if fd != nil {
-					if a := fd.getArg(av.Arg); a != nil {
-						at = a.Type
-					}
-				}
-				if av.Value != nil {
-					required[av.Arg] = true
-				}
-				var ea2 []error
-				args[av.Arg], ea2 = root.replaceArgVars(vars, av.Value, at)
-				Errors(ea2).in(av.Arg)
-				ea = append(ea, ea2...)
-			}
+	if pf.hasArgs {
+		args = make(map[string]interface{}, len(pf.args))
+		for _, pa := range pf.args {
+			var ea2 []error
+			args[pa.av.Arg], ea2 = root.replaceArgVars(ex, pa.av.Value, pa.t)
+			Errors(ea2).in(pa.av.Arg)
+			ea = append(ea, ea2...)
 		}
 	}
-	for k, v := range required {
-		if !v {
-			ea = append(ea, resWarn(field.line, field.col, "%s is required but missing", k))
-		}
+	for _, name := range pf.missing {
+		ea = append(ea, inputError(resWarn(pf.field.line, pf.field.col, "%s is required but missing", name)))
 	}
 	return
 }
 
-func (root *Root) replaceArgVars(vars map[string]interface{}, v interface{}, at Type) (val interface{}, ea []error) {
+func (root *Root) replaceArgVars(ex *execution, v interface{}, at Type) (val interface{}, ea []error) {
 	var err error
 	var ea2 []error
 	val = v
 	switch tv := val.(type) {
 	case Var:
-		val = vars[string(tv)]
+		val = ex.vars[string(tv)]
 		if at != nil {
 			if ic, _ := at.(InCoercer); ic != nil { // validated in SDL validation
-				if val, err = ic.CoerceIn(val); err != nil {
-					ea = append(ea, resWarnp(nil, "%s", err))
+				if val, err = coerceIn(ic, val, ex.opts.Relaxed); err != nil {
+					ea = append(ea, inputError(resWarnp(nil, "%s", err)))
 				}
 			}
 		}
 	case map[string]interface{}:
+		// The value is part of the executable so a new map is formed
+		// instead of modifying the original.
 		if it, _ := BaseType(at).(*Input); it != nil {
+			m := make(map[string]interface{}, len(tv))
 			for k, v := range tv {
 				var vt Type
 				if f := it.fields.get(k); f != nil {
 					vt = f.Type
 				}
-				tv[k], ea2 = root.replaceArgVars(vars, v, vt)
+				m[k], ea2 = root.replaceArgVars(ex, v, vt)
 				ea = append(ea, ea2...)
 			}
-			if val, err = it.CoerceIn(val); err != nil {
-				ea = append(ea, resWarnp(nil, "%s", err))
+			if val, err = it.coerceIn(m, ex.opts.Relaxed); err != nil {
+				ea = append(ea, inputError(resWarnp(nil, "%s", err)))
 			}
 		}
 	case []interface{}:
@@ -464,142 +661,182 @@ func (root *Root) replaceArgVars(vars map[string]interface{}, v interface{}, at
 		if lt, _ := at.(*List); lt != nil {
 			mt = lt.Base
 		}
+		list := make([]interface{}, len(tv))
 		for i, v := range tv {
-			tv[i], ea2 = root.replaceArgVars(vars, v, mt)
+			list[i], ea2 = root.replaceArgVars(ex, v, mt)
 			ea = append(ea, ea2...)
 		}
+		val = list
 	case Symbol:
 		bt := BaseType(at)
 		if et, _ := bt.(*Enum); et != nil {
 			if _, has := et.values.dict[string(tv)]; !has {
-				ea = append(ea, resWarnp(nil, "%s is not a valid enum value in %s", tv, et.N))
+				ea = append(ea, inputError(resWarnp(nil, "%s is not a valid enum value in %s", tv, et.N)))
+			} else if et.toGo != nil {
+				if val, err = et.coerceIn(val, ex.opts.Relaxed); err != nil {
+					ea = append(ea, inputError(resWarnp(nil, "%s", err)))
+				}
 			}
 		}
 	default:
 		if ic, _ := at.(InCoercer); ic != nil { // validated in SDL validation
-			if val, err = ic.CoerceIn(val); err != nil {
-				ea = append(ea, resWarnp(nil, "%s", err))
+			if val, err = coerceIn(ic, val, ex.opts.Relaxed); err != nil {
+				ea = append(ea, inputError(resWarnp(nil, "%s", err)))
 			}
 		}
 	}
 	return
 }
 
+// resolveField resolves the value of a field on obj. All the fields in pfs
+// have the same response name and the selections of all of them are
+// merged. The value is resolved using the first field. False is returned
+// if the field should not be included in the result.
 func (root *Root) resolveField(
 	obj interface{},
-	vars map[string]interface{},
-	field *Field,
-	t Type,
-	result map[string]interface{},
-	depth int) (ea []error) {
+	ex *execution,
+	pfs []*planField,
+	depth int) (fv interface{}, ok bool, ea []error) {
 
-	if field.ConType == nil {
-		field.ConType = t
-		ea = append(ea, field.sortArgs()...)
-		if 0 < len(ea) {
-			Errors(ea).in(field.key())
-			return
-		}
+	pf := pfs[0]
+	if 0 < len(pf.fails) {
+		return nil, false, freshErrors(pf.fails)
 	}
-	const queryType = "Query"
+	field := pf.field
+	ex.restrict(&pf.hint)
+	defer func() {
+		if r := recover(); r != nil {
+			fv, ok = nil, true
+			ea = append(ea, root.panicError(field, r))
+			if depth < ex.maxDepth {
+				Errors(ea).in(field.key())
+			}
+		}
+	}()
+	t := field.ConType
 	var ea2 []error
-	switch field.Name {
+	switch pf.meta {
 	case "__typename":
-		result[field.key()] = t.Name()
-		return nil
+		return root.typename(obj, t), true, nil
 	case "__type":
-		if t.Name() == queryType {
-			var fv interface{} // field value
-			var av *ArgValue
-
-			for _, av = range field.Args {
-				if av.Arg == nameStr {
-					break
-				}
-			}
-			if av == nil {
-				ea = append(ea, resWarnp(field, "__type meta-field is missing a name argument"))
-			} else {
-				var nv interface{}
-				if vr, ok := av.Value.(Var); ok && vars != nil {
-					nv = vars[string(vr)]
-				} else {
-					nv = av.Value
-				}
-				name, _ := nv.(string)
-				t = root.GetType(name)
-				if t != nil {
-					fv, ea2 = root.resolve(t, vars, field, root.GetType("__Type"), depth)
-					ea = append(ea, ea2...)
-					Errors(ea).in(field.key())
-				}
+		if t.Name() == queryTypeStr {
+			nt, ea2 := root.typeArg(ex, pf)
+			ea = append(ea, ea2...)
+			if nt != nil {
+				fv, ea2 = root.resolve(nt, ex, pfs, pf.ft, depth)
+				ea = append(ea, ea2...)
+				Errors(ea).in(field.key())
 			}
-			result[field.key()] = fv
-			return
+			return fv, true, ea
 		}
-		ea = append(ea, resWarnp(field, "__type meta-field is only on the query object"))
-		return
+		return nil, false, []error{resWarnp(field, "__type meta-field is only on the query object")}
 	case "__schema":
-		if t.Name() == queryType {
-			var fv interface{} // field value
-
-			fv, ea2 = root.resolve(root, vars, field, root.uuSchemaType, depth)
-			ea = append(ea, ea2...)
+		if t.Name() == queryTypeStr {
+			fv, ea = root.resolve(root, ex, pfs, pf.ft, depth)
 			Errors(ea).in(field.key())
-			result[field.key()] = fv
-			return
+			return fv, true, ea
 		}
-		ea = append(ea, resWarnp(field, "__schema meta-field is only on the query object"))
-		return
+		return nil, false, []error{resWarnp(field, "__schema meta-field is only on the query object")}
 	}
-	// Find the attribute of the object first.
-	var attr interface{}
-	var err error
+	attr, ea := root.fieldValue(obj, ex, pf)
+	if !IsNil(attr) {
+		fv, ea2 = root.resolve(attr, ex, pfs, pf.ft, depth)
+		ea = append(ea, ea2...)
+	}
+	if depth < ex.maxDepth && 0 < len(ea) {
+		Errors(ea).in(field.key())
+	}
+	return fv, true, ea
+}
 
-	res, _ := obj.(Resolver)
-	fd := root.getFieldDef(t, field.Name)
-	if fd == nil {
-		ea = append(ea, resWarnp(field, "%s is not a field in %s", field.Name, t.Name()))
-		return
+// panicError returns the error for a panic recovered while resolving a
+// field after calling the PanicHook of the root if there is one. It must be
+// called from the deferred function that recovered so the stack trace
+// includes the panic.
+func (root *Root) panicError(field *Field, r interface{}) error {
+	if root.PanicHook != nil {
+		root.PanicHook(field, r, debug.Stack())
+	}
+	return &Error{
+		Base:   fmt.Errorf("%w: %v", ErrPanic, r),
+		Line:   field.line,
+		Column: field.col,
 	}
+}
+
+// typename returns the value of the __typename meta-field.
+func (root *Root) typename(obj interface{}, t Type) string {
+	if _, ok := t.(*Interface); ok {
+		if ot := root.objectTypeOf(obj); ot != nil {
+			return ot.Name()
+		}
+	}
+	return t.Name()
+}
+
+// typeArg returns the type named by the name argument of the __type
+// meta-field.
+func (root *Root) typeArg(ex *execution, pf *planField) (Type, []error) {
+	var av *ArgValue
+
+	for _, av = range pf.field.Args {
+		if av.Arg == nameStr {
+			break
+		}
+	}
+	if av == nil {
+		return nil, []error{resWarnp(pf.field, "__type meta-field is missing a name argument")}
+	}
+	var nv interface{}
+	if vr, ok := av.Value.(Var); ok && ex.vars != nil {
+		nv = ex.vars[string(vr)]
+	} else {
+		nv = av.Value
+	}
+	name, _ := nv.(string)
+	if t := root.GetType(name); t != nil {
+		return t, nil
+	}
+	return nil, nil
+}
+
+// fieldValue returns the value of the field on obj. The Resolver interface
+// is used if obj implements it, then the AnyResolver of the root, and
+// finally reflection.
+func (root *Root) fieldValue(
+	obj interface{},
+	ex *execution,
+	pf *planField) (attr interface{}, ea []error) {
+
+	var err error
+	res, _ := obj.(Resolver)
 	switch {
 	case res != nil:
 		var args map[string]interface{}
-		if args, ea2 = root.formArgs(vars, field, fd); len(ea2) == 0 {
-			attr, err = res.Resolve(field, args)
+		if args, ea = root.formArgs(ex, pf); len(ea) == 0 {
+			attr, err = res.Resolve(pf.field, args)
 		}
-		ea = append(ea, ea2...)
 	case root.AnyResolver != nil:
 		var args map[string]interface{}
-		if args, ea2 = root.formArgs(vars, field, fd); len(ea2) == 0 {
-			attr, err = root.AnyResolver.Resolve(obj, field, args)
+		if args, ea = root.formArgs(ex, pf); len(ea) == 0 {
+			attr, err = root.AnyResolver.Resolve(obj, pf.field, args)
 		}
-		ea = append(ea, ea2...)
 	default:
-		attr, ea2 = root.resolveReflect(obj, vars, field, t)
-		ea = append(ea, ea2...)
+		attr, ea = root.resolveReflect(obj, ex, pf)
 	}
 	if err != nil {
-		ea = root.addError(field, ea, err)
-	}
-	if IsNil(attr) {
-		result[field.key()] = nil
-	} else {
-		var ft Type
-		if fd != nil {
-			ft = fd.Type
-		}
-		var fv interface{} // field value
-		fv, ea2 = root.resolve(attr, vars, field, ft, depth)
-		ea = append(ea, ea2...)
-		result[field.key()] = fv
-	}
-	if depth < MaxResolveDepth {
-		Errors(ea).in(field.key())
+		ea = root.addError(pf.field, ea, err)
 	}
 	return
 }
 
+// addError adds an error returned by a resolver for a field. Each member
+// of an Errors is added separately. The Path of an Error is relative to the
+// field so a resolver can return a value along with errors for parts of
+// that value such as an index in a list. The path is made fully qualified
+// as the errors are returned up through the parent fields. A Line and
+// Column set by the resolver are kept, otherwise the location of the field
+// is used.
 func (root *Root) addError(f *Field, ea []error, err error) []error {
 	var es Errors
 	var e1 *Error
@@ -609,9 +846,20 @@ func (root *Root) addError(f *Field, ea []error, err error) []error {
 			ea = root.addError(f, ea, e)
 		}
 	case errors.As(err, &e1):
-		err = resWarn(f.line, f.col, "%s", err)
-		err.(*Error).Extensions = e1.Extensions //nolint:errorlint
-		ea = append(ea, err)
+		var e *Error
+		if err == e1 { //nolint:errorlint
+			e = resWarn(f.line, f.col, "%s", e1.Base).(*Error) //nolint:errorlint
+			e.Path = append(e.Path, e1.Path...)
+			if e1.Line != 0 {
+				e.Line = e1.Line
+				e.Column = e1.Column
+			}
+		} else {
+			e = resWarn(f.line, f.col, "%s", err).(*Error) //nolint:errorlint
+		}
+		e.Extensions = e1.Extensions
+		e.code = e1.code
+		ea = append(ea, e)
 	default:
 		ea = append(ea, resWarn(f.line, f.col, "%s", err))
 	}
@@ -620,122 +868,205 @@ func (root *Root) addError(f *Field, ea []error, err error) []error {
 
 func (root *Root) resolveReflect(
 	obj interface{},
-	vars map[string]interface{},
-	field *Field,
-	t Type) (value interface{}, ea []error) {
+	ex *execution,
+	pf *planField) (value interface{}, ea []error) {
 
+	field := pf.field
+	acc, _ := pf.acc.Load().(*reflectAcc)
+	if acc == nil || acc.meta != reflect.TypeOf(obj) {
+		if acc, ea = root.reflectAccessor(obj, field); acc == nil {
+			return
+		}
+		pf.acc.Store(acc)
+	}
 	ov := reflect.ValueOf(obj)
-	var fd *FieldDef
-	var err error
+	switch {
+	case acc.index != nil:
+		if ov.Kind() == reflect.Ptr {
+			ov = ov.Elem()
+		}
+		if ov.Kind() == reflect.Struct {
+			value = ov.FieldByIndex(acc.index).Interface()
+		}
+	case acc.method != nil:
+		argMap, ea2 := root.formArgs(ex, pf)
+		if 0 < len(ea2) {
+			return nil, append(ea, ea2...)
+		}
+		args, ea2 := root.formReflectArgs(ex, ov, argMap, field, acc.fd, acc.method.Type())
+		if 0 < len(ea2) {
+			return nil, append(ea, ea2...)
+		}
+		mva := acc.method.Call(args)
+		switch len(mva) {
+		case 1:
+			value = mva[0].Interface()
+		case 2: // assume (interface{}, error) return
+			value = mva[0].Interface()
+			if err, _ := mva[1].Interface().(error); err != nil {
+				ea = root.addError(field, ea, err)
+			}
+		default:
+			ea = append(ea, resWarn(field.line, field.col, "%T.%s returned more than 2 values", obj, field.Name))
+		}
+	}
+	return
+}
+
+// reflectAccessor returns a description of how to get the field value from
+// an object of the same Go type as obj. The Go type is registered for the
+// object type and the Go struct field or method is registered for the field
+// if not already registered.
+func (root *Root) reflectAccessor(obj interface{}, field *Field) (acc *reflectAcc, ea []error) {
+	meta := reflect.TypeOf(obj)
+	var ot *Object
+	t := field.ConType
 TOP:
 	switch tt := t.(type) {
 	case *Object:
-		_ = root.assureType(obj, tt)
-		if fd = tt.GetField(field.Name); fd != nil {
-			fd.mu.Lock()
-			if len(fd.goField) == 0 && fd.method == nil {
-				err = root.regField(tt, fd, field.Name)
-			}
-			fd.mu.Unlock()
-		}
+		ot = tt
 	case *Schema:
-		_ = root.assureType(obj, &tt.Object)
-		if fd = tt.GetField(field.Name); fd != nil {
-			fd.mu.Lock()
-			if len(fd.goField) == 0 && fd.method == nil {
-				err = root.regField(&tt.Object, fd, field.Name)
-			}
-			fd.mu.Unlock()
-		}
+		ot = &tt.Object
 	case *Interface:
 		// Determine actual type based on the obj and try again.
-		t = root.getReflectType(ov.Type())
+		t = root.getReflectType(meta)
 		goto TOP
 	}
-	if err != nil {
-		ea = append(ea, resWarn(field.line, field.col, "%s", err))
+	if ot == nil || meta == nil {
 		return
 	}
-	if fd != nil {
-		fd.mu.Lock()
-		goField := fd.goField
-		method := fd.method
-		fd.mu.Unlock()
-		switch {
-		case 0 < len(goField):
-			if ov.Kind() == reflect.Ptr {
-				ov = ov.Elem()
-			}
-			if ov.Kind() == reflect.Struct {
-				if fv := ov.FieldByName(fd.goField); fv.IsValid() {
-					value = fv.Interface()
-				}
-			}
-		case method != nil:
-			args := root.formReflectArgs(ov, vars, field)
-			mva := fd.method.Call(args)
-			switch len(mva) {
-			case 1:
-				value = mva[0].Interface()
-			case 2: // assume (interface{}, error) return
-				value = mva[0].Interface()
-				if err, _ = mva[1].Interface().(error); err != nil {
-					ea = append(ea, resWarn(field.line, field.col, "%s", err))
+	_ = root.assureType(obj, ot)
+	fd := ot.GetField(field.Name)
+	if fd == nil {
+		return
+	}
+	var err error
+	fd.mu.Lock()
+	if len(fd.goIndex) == 0 && fd.method == nil {
+		err = root.regField(ot, fd, field.Name)
+	}
+	goIndex := fd.goIndex
+	method := fd.method
+	fd.mu.Unlock()
+	if err != nil {
+		return nil, []error{resWarn(field.line, field.col, "%s", err)}
+	}
+	return newReflectAcc(meta, fd, goIndex, method), nil
+}
+
+// formReflectArgs builds the arguments for a reflection method call. The
+// coerced field arguments are bound in the order of the FieldDef arguments,
+// which is the schema order unless changed with RegisterField. Missing
+// arguments take the schema default value coerced with the Relaxed option of
+// the request. If the method takes a single struct or struct pointer
+// parameter and the field does not have a single input object argument then
+// the argument values are set on the matching fields of the struct instead. Otherwise the first field arguments are
+// passed as the method parameters. A method with fewer parameters than the
+// field has arguments ignores the rest but a method with more parameters
+// than the field has arguments can not be called.
+func (root *Root) formReflectArgs(
+	ex *execution,
+	ov reflect.Value,
+	argMap map[string]interface{},
+	field *Field,
+	fd *FieldDef,
+	mt reflect.Type) (args []reflect.Value, ea []error) {
+
+	values := make(map[string]interface{}, fd.args.Len())
+	for _, a := range fd.args.list {
+		v, has := argMap[a.N]
+		if !has && a.Default != nil {
+			v = a.Default
+			if ic, _ := a.Type.(InCoercer); ic != nil {
+				var err error
+				if v, err = coerceIn(ic, v, ex.opts.Relaxed); err != nil {
+					ea = append(ea, resWarn(field.line, field.col, "%s", inErr(err, a.N)))
+					continue
 				}
-			default:
-				ea = append(ea, resWarn(field.line, field.col, "%T.%s returned more than 2 values", obj, field.Name))
 			}
 		}
+		values[a.N] = v
 	}
-	return
-}
-
-func (root *Root) formReflectArgs(ov reflect.Value, vars map[string]interface{}, field *Field) (args []reflect.Value) {
-	args = make([]reflect.Value, 0, len(field.Args)+1)
+	if 0 < len(ea) {
+		return
+	}
+	args = make([]reflect.Value, 0, mt.NumIn())
 	args = append(args, ov)
-	// Build the args by combining provided args and variable values as
-	// appropriate.
-	for _, av := range field.Args {
-		if vr, ok := av.Value.(Var); ok && vars != nil {
-			args = append(args, reflect.ValueOf(vars[string(vr)]))
-		} else {
-			args = append(args, reflect.ValueOf(av.Value))
+	if mt.NumIn() == 2 && isArgStruct(mt.In(1)) && !isSingleInputArg(fd) {
+		pv := reflect.New(mt.In(1)).Elem()
+		sv := pv
+		if sv.Kind() == reflect.Ptr {
+			sv.Set(reflect.New(sv.Type().Elem()))
+			sv = sv.Elem()
 		}
+		for _, a := range fd.args.list {
+			sf, ok := findGoField(sv.Type(), a.N, root.JSONTags)
+			if !ok {
+				ea = append(ea, resWarn(field.line, field.col, "%s: %s is not a field of %s", ErrMeta, a.N, sv.Type()))
+				continue
+			}
+			if err := decodeValue(fieldByIndex(sv, sf.Index), values[a.N], root.JSONTags); err != nil {
+				ea = append(ea, resWarn(field.line, field.col, "%s", inErr(err, a.N)))
+			}
+		}
+		return append(args, pv), ea
+	}
+	if fd.args.Len() < mt.NumIn()-1 {
+		ea = append(ea, resWarn(field.line, field.col, "%s: method for %s takes %d arguments but the field has %d",
+			ErrMeta, fd.N, mt.NumIn()-1, fd.args.Len()))
+		return
+	}
+	for i := 1; i < mt.NumIn(); i++ {
+		a := fd.args.list[i-1]
+		pv := reflect.New(mt.In(i)).Elem()
+		if err := decodeValue(pv, values[a.N], root.JSONTags); err != nil {
+			ea = append(ea, resWarn(field.line, field.col, "%s", inErr(err, a.N)))
+			continue
+		}
+		args = append(args, pv)
 	}
 	return
 }
 
-func (root *Root) resolveInline(
-	obj interface{},
-	vars map[string]interface{},
-	sel *Inline,
-	t Type,
-	result map[string]interface{},
-	depth int) (ea []error) {
-
-	if sel.Condition == nil || sel.Condition == t {
-		ea = root.resolveSels(obj, vars, sel.Sels, t, result, depth)
+func isArgStruct(rt reflect.Type) bool {
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
 	}
-	return
+	return rt.Kind() == reflect.Struct && rt != timeType
 }
 
-func (root *Root) resolveFragRef(
-	obj interface{},
-	vars map[string]interface{},
-	sel *FragRef,
-	t Type,
-	result map[string]interface{},
-	depth int) (ea []error) {
+func isSingleInputArg(fd *FieldDef) bool {
+	if fd.args.Len() == 1 {
+		_, ok := BaseType(fd.args.list[0].Type).(*Input)
+		return ok
+	}
+	return false
+}
 
-	if sel.Fragment.Condition == nil || sel.Fragment.Condition == t {
-		ea = root.resolveSels(obj, vars, sel.Fragment.Sels, t, result, depth)
-		if 0 < len(ea) {
-			Errors(ea).in(fmt.Sprintf("fragment at %d:%d", sel.Line(), sel.Column()))
+// fragSet returns the selections of the fragment to resolve on obj or nil if
+// the fragment does not apply to obj.
+func (root *Root) fragSet(obj interface{}, pf *planFrag) (set *planSet) {
+	if set = pf.static; set == nil && pf.sets != nil {
+		// The container is an interface or union so the object type
+		// determines if the fragment applies.
+		if ot := root.objectTypeOf(obj); ot != nil {
+			set = pf.sets[ot]
 		}
 	}
 	return
 }
 
+// objectTypeOf returns the object type of obj or nil if it can not be
+// determined.
+func (root *Root) objectTypeOf(obj interface{}) *Object {
+	if tv, ok := obj.(typedValue); ok {
+		return tv.objectType()
+	}
+	ot, _ := root.getReflectType(reflect.TypeOf(obj)).(*Object)
+
+	return ot
+}
+
 func (root *Root) getFieldDef(t Type, name string) (fd *FieldDef) {
 	switch tt := t.(type) {
 	case *Object:
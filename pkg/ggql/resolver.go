@@ -21,5 +21,9 @@ type Resolver interface {
 	// field to resolve. The args parameter includes the values associated
 	// with the arguments provided by the caller. The function should return
 	// the field's object or an error. A return of nil is also possible.
+	// Both a value and an error can be returned for partial results. The
+	// value is still resolved and an Errors with an Error for each failed
+	// part can be returned where the Path of each Error is relative to the
+	// field, such as []interface{}{3} for the fourth member of a list.
 	Resolve(field *Field, args map[string]interface{}) (interface{}, error)
 }
@@ -485,8 +485,14 @@ func TestResolveInterfaceUUschemaDirs(t *testing.T) {
         {
           "name": "include"
         },
+        {
+          "name": "oneOf"
+        },
         {
           "name": "skip"
+        },
+        {
+          "name": "specifiedBy"
         }
       ]
     }
@@ -892,6 +898,8 @@ func TestResolveInterfaceDir(t *testing.T) {
           "description": "",
           "locations": [
             "FIELD_DEFINITION",
+            "ARGUMENT_DEFINITION",
+            "INPUT_FIELD_DEFINITION",
             "ENUM_VALUE"
           ],
           "name": "deprecated"
@@ -936,6 +944,15 @@ func TestResolveInterfaceDir(t *testing.T) {
           ],
           "name": "include"
         },
+        {
+          "args": [
+          ],
+          "description": "",
+          "locations": [
+            "INPUT_OBJECT"
+          ],
+          "name": "oneOf"
+        },
         {
           "args": [
             {
@@ -949,6 +966,18 @@ func TestResolveInterfaceDir(t *testing.T) {
             "INLINE_FRAGMENT"
           ],
           "name": "skip"
+        },
+        {
+          "args": [
+            {
+              "name": "url"
+            }
+          ],
+          "description": "",
+          "locations": [
+            "SCALAR"
+          ],
+          "name": "specifiedBy"
         }
       ]
     }
@@ -973,6 +1002,10 @@ func TestResolveInterfaceDirError(t *testing.T) {
         {
         },
         {
+        },
+        {
+        },
+        {
         }
       ]
     }
@@ -1052,6 +1085,36 @@ func TestResolveInterfaceDirError(t *testing.T) {
         4,
         "bad"
       ]
+    },
+    {
+      "locations": [
+        {
+          "column": 29,
+          "line": 1
+        }
+      ],
+      "message": "resolve error: bad is not a field in __Directive",
+      "path": [
+        "__schema",
+        "directives",
+        5,
+        "bad"
+      ]
+    },
+    {
+      "locations": [
+        {
+          "column": 29,
+          "line": 1
+        }
+      ],
+      "message": "resolve error: bad is not a field in __Directive",
+      "path": [
+        "__schema",
+        "directives",
+        6,
+        "bad"
+      ]
     }
   ]
 }
@@ -1418,7 +1481,8 @@ extend type Artist implements Named {
           "name": "name"
         }
       ],
-      "interfaces": null,
+      "interfaces": [
+      ],
       "kind": "INTERFACE",
       "name": "Named",
       "possibleTypes": [
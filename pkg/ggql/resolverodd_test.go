@@ -39,6 +39,11 @@ type Query {
   size(list: [Int!]): Int
   lunch(what: Bento!): String
   errorExtension: Int
+  parts: [Part]
+}
+
+type Part {
+  nums: [Int]
 }
 
 "Japanese lunch box"
@@ -61,6 +66,9 @@ type oddSchema struct {
 type oddQuery struct {
 }
 
+type oddPart struct {
+}
+
 func (s *oddSchema) Resolve(field *ggql.Field, args map[string]interface{}) (interface{}, error) {
 	switch field.Name {
 	case "query":
@@ -107,6 +115,8 @@ func (q *oddQuery) Resolve(field *ggql.Field, args map[string]interface{}) (inte
 	case "size":
 		list, _ := args["list"].([]interface{})
 		return len(list), nil
+	case "parts":
+		return []interface{}{&oddPart{}, &oddPart{}}, nil
 	case "errorExtension":
 		return 1, &ggql.Error{
 			Base: fmt.Errorf("some error"),
@@ -118,6 +128,22 @@ func (q *oddQuery) Resolve(field *ggql.Field, args map[string]interface{}) (inte
 	return nil, fmt.Errorf("type Query does not have field %s", field)
 }
 
+func (p *oddPart) Resolve(field *ggql.Field, args map[string]interface{}) (interface{}, error) {
+	if field.Name == "nums" {
+		return []interface{}{0, 1, 2, nil, 4, nil}, ggql.Errors{
+			&ggql.Error{Base: fmt.Errorf("lost three"), Path: []interface{}{3}},
+			&ggql.Error{
+				Base:       fmt.Errorf("five is late"),
+				Path:       []interface{}{5},
+				Line:       7,
+				Column:     11,
+				Extensions: map[string]interface{}{"code": "PARTIAL"},
+			},
+		}
+	}
+	return nil, fmt.Errorf("type Part does not have field %s", field)
+}
+
 func testOddResolve(t *testing.T, src, expect string, vars map[string]interface{}) {
 	ggql.Sort = true
 	root := ggql.NewRoot(&oddSchema{})
@@ -307,9 +333,10 @@ func TestResolveInterfaceNestedErrors(t *testing.T) {
           "line": 1
         }
       ],
-      "message": "resolve error: error two at here",
+      "message": "resolve error: error two",
       "path": [
-        "nestErrors"
+        "nestErrors",
+        "here"
       ]
     }
   ]
@@ -480,3 +507,26 @@ func TestResolveErrorWithExtensions(t *testing.T) {
 
 	testOddResolve(t, src, expect, nil)
 }
+
+func TestResolveInterfacePartialErrors(t *testing.T) {
+	ggql.Sort = true
+	root := ggql.NewRoot(&oddSchema{})
+	err := root.ParseString(oddSdl)
+	checkNil(t, err, "no error should be returned when parsing a valid SDL. %s", err)
+
+	src := `{parts{nums}}`
+	expect := `{"data":{"parts":[{"nums":[0,1,2,null,4,null]},{"nums":[0,1,2,null,4,null]}]},"errors":[` +
+		`{"locations":[{"column":9,"line":1}],"message":"resolve error: lost three","path":["parts",0,"nums",3]},` +
+		`{"extensions":{"code":"PARTIAL"},"locations":[{"column":11,"line":7}],"message":"resolve error: five is late","path":["parts",0,"nums",5]},` +
+		`{"locations":[{"column":9,"line":1}],"message":"resolve error: lost three","path":["parts",1,"nums",3]},` +
+		`{"extensions":{"code":"PARTIAL"},"locations":[{"column":11,"line":7}],"message":"resolve error: five is late","path":["parts",1,"nums",5]}]}`
+
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, root.ResolveString(src, "", nil), -1)
+	checkEqual(t, expect, b.String(), "partial results with errors")
+
+	b.Reset()
+	err = root.StreamString(&b, src, "", nil)
+	checkNil(t, err, "stream failed. %s", err)
+	checkEqual(t, expect, b.String(), "streamed partial results with errors")
+}
@@ -20,6 +20,7 @@ import (
 	"io"
 	"io/fs"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -28,7 +29,7 @@ import (
 // to GraphQL types. For example a string can be coerced into an enum or a
 // JSON object can be converted into a GraphQL input type. Note that turning
 // this on goes against the spec since strings should not be coerced into
-// enums.
+// enums. It is the default for the Relaxed option of a Root.
 var Relaxed = false
 
 // Root the root of a GraphQL schema.
@@ -40,9 +41,71 @@ type Root struct {
 	uuSchemaType  *uuSchema
 	AnyResolver   AnyResolver
 	subscriptions []*Subscription
-	subLock       sync.Mutex
-	excludeTime   bool
-	excludeInt64  bool
+
+	// JSONTags if true allows json struct tags to be used when mapping
+	// GraphQL fields to Go struct fields with the reflection resolver. A
+	// graphql struct tag always takes precedence over a json tag.
+	JSONTags bool
+
+	// DeprecationWarnings if true adds a warning to the response
+	// "extensions" for each use of a deprecated argument or input field in
+	// a request.
+	DeprecationWarnings bool
+
+	// PersistedQueries is the store used by ResolveRequest() for persisted
+	// queries. If nil then persisted queries are not supported.
+	PersistedQueries PersistedQueryStore
+
+	// PersistedOnly if true rejects any request to ResolveRequest() with
+	// a query that is not already in the PersistedQueries store.
+	PersistedOnly bool
+
+	// ExecutableCacheSize is the maximum number of compiled plans kept by
+	// ResolveBytes(), ResolveString(), and ResolveReader() so that repeated
+	// requests with the same document and operation are not parsed,
+	// validated, and compiled again. The cache is cleared when the schema
+	// changes or a Go type or field is registered. Zero, the default,
+	// disables the cache.
+	ExecutableCacheSize int
+
+	// ResponseCache if not nil is used to cache whole query responses
+	// according to the CachePolicy determined by the @cacheControl
+	// directives of the fields resolved. The directive is added with
+	// AddCacheControl() or declared in the schema. Responses with errors
+	// are not cached. Streamed responses do not use the cache.
+	ResponseCache ResponseCache
+
+	// DefaultMaxAge is the maxAge in seconds of fields of the operation
+	// type and fields that return an object, interface, or union when
+	// there is no @cacheControl maxAge on the field or the field type. The
+	// default of zero makes responses not cacheable unless all such fields
+	// have a maxAge.
+	DefaultMaxAge int
+
+	// ErrorCodes if true adds a "code" extension, as returned by
+	// ErrorCode(), to each error in a response that does not already have
+	// one.
+	ErrorCodes bool
+
+	// ErrorPresenter if not nil is called with each error before it is
+	// added to a response. The returned error is used in place of the
+	// original and if nil the error is left out of the response. It can be
+	// used to hide the details of internal errors for example. Errors that
+	// are not an *Error are wrapped in one before being presented.
+	ErrorPresenter func(err *Error) *Error
+
+	// PanicHook if not nil is called with the field, the recovered value,
+	// and the stack trace when a panic occurs while resolving a field. The
+	// panic is reported as an ErrPanic error on the field and the rest of
+	// the response is still resolved.
+	PanicHook func(field *Field, value interface{}, stack []byte)
+
+	opts         *Options
+	exeCache     exeCache
+	reflectTypes reflectTypeCache
+	subLock      sync.Mutex
+	excludeTime  bool
+	excludeInt64 bool
 }
 
 // NewRoot creates a new GraphQL schema root with a root resolver object. The
@@ -64,6 +127,17 @@ func NewRoot(obj interface{}, exclude ...string) *Root {
 	return &root
 }
 
+// AddCacheControl adds the built in CacheControlScope enum and the
+// @cacheControl directive to the schema so they can be used in an SDL
+// parsed afterwards. A schema that declares its own @cacheControl with
+// maxAge and scope arguments can be used without calling AddCacheControl.
+func (root *Root) AddCacheControl() error {
+	root.init()
+	scope := root.newCacheControlScope()
+
+	return root.AddTypes(scope, root.newCacheControlDirective(scope))
+}
+
 // AddTypes adds types to the schema.
 func (root *Root) AddTypes(types ...Type) (err error) {
 	root.init()
@@ -93,6 +167,12 @@ func (root *Root) Types() []Type {
 	return root.types.list
 }
 
+// Schema returns the schema of the root or nil if no schema has been
+// defined yet.
+func (root *Root) Schema() *Schema {
+	return root.schema
+}
+
 // RegisterType associates a Go type with a GraphQL type. This is only needed
 // for Object or Schema types used in GraphQL unions or more accurately the
 // condition of a fragment when used with a union. It should also be used when
@@ -103,9 +183,62 @@ func (root *Root) RegisterType(sample interface{}, gqlType string) error {
 		return err
 	}
 	if obj != nil {
-		return root.assureType(sample, obj)
+		err = root.assureType(sample, obj)
+	} else {
+		err = root.regInput(sample, input)
 	}
-	return root.regInput(sample, input)
+	if err == nil {
+		root.clearCaches()
+	}
+	return err
+}
+
+// RegisterEnum associates a Go type, typically a named int type with
+// constants, with a GraphQL enum. Each value is mapped to the enum value
+// named by the value's String() method. Enum arguments are then coerced to
+// the Go values and Go values returned by resolvers are coerced to the enum
+// value names. An error is returned if a name is not a value of the enum in
+// which case none of the values are registered.
+func (root *Root) RegisterEnum(gqlType string, values ...fmt.Stringer) error {
+	et, err := root.getEnumType(gqlType)
+	if err == nil {
+		names := make([]string, len(values))
+		vals := make([]interface{}, len(values))
+		for i, v := range values {
+			names[i] = v.String()
+			vals[i] = v
+		}
+		err = et.bind(names, vals)
+	}
+	return err
+}
+
+// RegisterEnumValues associates Go values with GraphQL enum values by
+// explicitly mapping enum value names to Go values. All the Go values must
+// be of the same type. If any value can not be registered none are.
+func (root *Root) RegisterEnumValues(gqlType string, values map[string]interface{}) error {
+	et, err := root.getEnumType(gqlType)
+	if err == nil {
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		vals := make([]interface{}, len(names))
+		for i, name := range names {
+			vals[i] = values[name]
+		}
+		err = et.bind(names, vals)
+	}
+	return err
+}
+
+func (root *Root) getEnumType(gqlType string) (*Enum, error) {
+	root.init()
+	if et, ok := root.types.get(gqlType).(*Enum); ok {
+		return et, nil
+	}
+	return nil, fmt.Errorf("%s %w or not an Enum", gqlType, ErrNotFound)
 }
 
 func (root *Root) getObjType(gqlType string) (obj *Object, input *Input, err error) {
@@ -130,11 +263,17 @@ func (root *Root) getObjType(gqlType string) (obj *Object, input *Input, err err
 func (root *Root) assureType(sample interface{}, obj *Object) error {
 	meta := reflect.TypeOf(sample)
 	obj.mu.Lock()
-	defer obj.mu.Unlock()
-	if obj.meta != nil && obj.meta != meta {
-		return fmt.Errorf("%w: %s is already registered as a %s", ErrDuplicate, obj.N, obj.meta.String())
+	if obj.meta != nil {
+		defer obj.mu.Unlock()
+		if obj.meta != meta {
+			return fmt.Errorf("%w: %s is already registered as a %s", ErrDuplicate, obj.N, obj.meta.String())
+		}
+		return nil
 	}
 	obj.meta = meta
+	obj.mu.Unlock()
+	// Go types that did not match an object type before may match now.
+	root.reflectTypes.clear()
 
 	return nil
 }
@@ -145,12 +284,64 @@ func (root *Root) regInput(sample interface{}, input *Input) error {
 		return fmt.Errorf("%w: %s is already registered as a %s", ErrDuplicate, input.N, input.meta.String())
 	}
 	input.meta = meta
+	input.root = root
 
 	return nil
 }
 
-func (root *Root) getReflectType(meta reflect.Type) (obj Type) {
-	for _, t := range root.types.list {
+// reflectTypeCache holds the object types found for Go types by
+// getReflectType. A nil object is kept for Go types that do not match any
+// object type. Any change to the schema replaces the type list so a
+// mismatch clears the cache.
+type reflectTypeCache struct {
+	mu    sync.Mutex
+	types *typeList
+	objs  map[reflect.Type]*Object
+}
+
+func (c *reflectTypeCache) get(types *typeList, meta reflect.Type) (obj *Object, has bool) {
+	c.mu.Lock()
+	if c.types == types {
+		obj, has = c.objs[meta]
+	}
+	c.mu.Unlock()
+
+	return
+}
+
+func (c *reflectTypeCache) put(types *typeList, meta reflect.Type, obj *Object) {
+	c.mu.Lock()
+	if c.objs == nil || c.types != types {
+		c.types = types
+		c.objs = map[reflect.Type]*Object{}
+	}
+	c.objs[meta] = obj
+	c.mu.Unlock()
+}
+
+func (c *reflectTypeCache) clear() {
+	c.mu.Lock()
+	c.objs = nil
+	c.mu.Unlock()
+}
+
+// clearCaches clears the cached plans and Go type matches that depend on the
+// Go types and fields registered.
+func (root *Root) clearCaches() {
+	root.exeCache.clear()
+	root.reflectTypes.clear()
+}
+
+func (root *Root) getReflectType(meta reflect.Type) Type {
+	types := root.types
+	if obj, has := root.reflectTypes.get(types, meta); has {
+		if obj == nil {
+			return nil
+		}
+		return obj
+	}
+	var obj *Object
+	for _, t := range types.list {
 		o, _ := t.(*Object)
 		if o != nil {
 			o.mu.Lock()
@@ -162,7 +353,28 @@ func (root *Root) getReflectType(meta reflect.Type) (obj Type) {
 			o.mu.Unlock()
 		}
 	}
-	return
+	if obj == nil && meta != nil {
+		// As with union members, fall back to the @go directive or the
+		// GraphQL type name when the Go type has not been registered.
+		for _, t := range types.list {
+			if o, _ := t.(*Object); o != nil && !o.core {
+				if m, err := o.metaCheck(meta); err == nil && m == meta {
+					obj = o
+					break
+				}
+			}
+		}
+	}
+	if meta != nil {
+		// An object type is never registered with a different Go type once
+		// the meta is set so a match stays valid until the schema changes.
+		// A miss is cleared when a Go type is registered.
+		root.reflectTypes.put(types, meta, obj)
+	}
+	if obj == nil {
+		return nil
+	}
+	return obj
 }
 
 // RegisterField registers a field for a type. This function should be used to
@@ -190,6 +402,9 @@ func (root *Root) RegisterField(gqlType, gqlField, goField string, args ...strin
 	fd.mu.Lock()
 	err = root.regField(obj, fd, goField, args...)
 	fd.mu.Unlock()
+	if err == nil {
+		root.clearCaches()
+	}
 	return err
 }
 
@@ -201,10 +416,19 @@ func (root *Root) regField(obj *Object, fd *FieldDef, goField string, args ...st
 		meta = meta.Elem()
 	}
 	if meta.Kind() == reflect.Struct {
-		if field, ok := meta.FieldByNameFunc(func(name string) bool {
-			return strings.EqualFold(name, goField)
-		}); ok {
-			fd.goField = field.Name
+		var field reflect.StructField
+		var ok bool
+		if goField == fd.N {
+			// Not explicitly registered so struct tags are honored and a
+			// field hidden with a "-" tag is never matched.
+			field, ok = findGoField(meta, goField, root.JSONTags)
+		} else {
+			field, ok = meta.FieldByNameFunc(func(name string) bool {
+				return strings.EqualFold(name, goField)
+			})
+		}
+		if ok {
+			fd.goIndex = field.Index
 			if 0 < len(args) {
 				err = fmt.Errorf("%w: field %s on %s does not have argument", ErrMeta, goField, meta)
 			}
@@ -480,9 +704,21 @@ func (root *Root) validateTypeName(typeName string, t Type) (errs []error) {
 	return
 }
 
-func (root *Root) validateDirUses(t Type) (errs []error) {
-	for _, du := range t.Directives() {
-		errs = append(errs, root.validateDirUse(t.Name(), Locate(t), du)...)
+func (root *Root) validateDirUses(t Type) []error {
+	return root.validateDirUseList(t.Name(), Locate(t), t.Directives())
+}
+
+func (root *Root) validateDirUseList(where string, loc Location, dus []*DirectiveUse) (errs []error) {
+	used := map[string]bool{}
+	for _, du := range dus {
+		errs = append(errs, root.validateDirUse(where, loc, du)...)
+		if d, _ := du.Directive.(*Directive); d != nil && !d.Repeatable {
+			if used[d.N] {
+				errs = append(errs, fmt.Errorf("%w, directive @%s is not repeatable but used more than once on %s at %d:%d",
+					ErrValidation, d.N, where, du.line, du.col))
+			}
+			used[d.N] = true
+		}
 	}
 	return
 }
@@ -566,6 +802,8 @@ func (root *Root) init() {
 	root.dirs.add(root.newIncludeDirective())
 	root.dirs.add(root.newDeprecatedDirective())
 	root.dirs.add(root.newGoDirective())
+	root.dirs.add(root.newSpecifiedByDirective())
+	root.dirs.add(root.newOneOfDirective())
 
 	// Okay to not check the error here as unit tests cover the case where an
 	// error could occur.
@@ -610,6 +848,9 @@ func (root *Root) replaceTypeRefs(t Type) (err error) {
 		if err = root.replaceFieldRefs(&tt.fields); err != nil {
 			return
 		}
+		if err = root.replaceInterfaceRefs(tt.Interfaces); err != nil {
+			return
+		}
 	case *Input:
 		if err = root.replaceInputFieldRefs(&tt.fields); err != nil {
 			return
@@ -774,8 +1015,9 @@ func (root *Root) replaceDirRefs(dirs []*DirectiveUse) (err error) {
 //   interfaces: [__Type!]
 //   possibleTypes: [__Type!]
 //   enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
-//   inputFields: [__InputValue!]
+//   inputFields(includeDeprecated: Boolean = false): [__InputValue!]
 //   ofType: __Type
+//   specifiedByURL: String
 // }.
 func (root *Root) newUuType(typeKind, strType Type) Type {
 	t := Object{
@@ -798,14 +1040,16 @@ func (root *Root) newUuType(typeKind, strType Type) Type {
 	_ = fd.args.add(&Arg{Base: Base{N: includeDeprecatedStr}, Type: root.types.get("Boolean"), Default: false})
 
 	_ = t.fields.add(fd)
-	_ = t.fields.add(&FieldDef{Base: Base{N: inputFieldsStr},
-		Type: &List{Base: &NonNull{Base: &Ref{Base: Base{N: "__InputValue"}}}},
-	})
+	fd = &FieldDef{Base: Base{N: inputFieldsStr}, Type: &List{Base: &NonNull{Base: &Ref{Base: Base{N: "__InputValue"}}}}}
+	_ = fd.args.add(&Arg{Base: Base{N: includeDeprecatedStr}, Type: root.types.get("Boolean"), Default: false})
+
+	_ = t.fields.add(fd)
 
 	typeList := &NonNull{Base: &List{Base: &NonNull{Base: &t}}}
 	_ = t.fields.add(&FieldDef{Base: Base{N: interfacesStr}, Type: typeList})
 	_ = t.fields.add(&FieldDef{Base: Base{N: possibleTypesStr}, Type: typeList})
 	_ = t.fields.add(&FieldDef{Base: Base{N: ofTypeStr}, Type: &t})
+	_ = t.fields.add(&FieldDef{Base: Base{N: specifiedByURLStr}, Type: strType})
 
 	return &t
 }
@@ -846,6 +1090,8 @@ func (root *Root) newUuSchema(uuType, uuDir Type) Type {
 //   description: String
 //   type: __Type!
 //   defaultValue: String
+//   isDeprecated: Boolean!
+//   deprecationReason: String
 // }.
 func (root *Root) newUuInputValue(uuType, strType Type) Type {
 	t := Object{
@@ -859,6 +1105,8 @@ func (root *Root) newUuInputValue(uuType, strType Type) Type {
 	_ = t.fields.add(&FieldDef{Base: Base{N: descriptionStr}, Type: strType})
 	_ = t.fields.add(&FieldDef{Base: Base{N: typeStr}, Type: &NonNull{Base: uuType}})
 	_ = t.fields.add(&FieldDef{Base: Base{N: defaultValueStr}, Type: strType})
+	_ = t.fields.add(&FieldDef{Base: Base{N: isDeprecatedStr}, Type: &NonNull{Base: root.types.get(booleanStr)}})
+	_ = t.fields.add(&FieldDef{Base: Base{N: deprecationReasonStr}, Type: strType})
 
 	return &t
 }
@@ -866,7 +1114,7 @@ func (root *Root) newUuInputValue(uuType, strType Type) Type {
 // type __Field {
 //   name: String!
 //   description: String
-//   args: [__InputValue!]!
+//   args(includeDeprecated: Boolean = false): [__InputValue!]!
 //   type: __Type!
 //   isDeprecated: Boolean!
 //   deprecationReason: String
@@ -881,9 +1129,10 @@ func (root *Root) newUuField(uuType, inputValue, strType Type) Type {
 	}
 	_ = t.fields.add(&FieldDef{Base: Base{N: nameStr}, Type: &NonNull{Base: strType}})
 	_ = t.fields.add(&FieldDef{Base: Base{N: descriptionStr}, Type: strType})
-	_ = t.fields.add(&FieldDef{Base: Base{N: "args"},
-		Type: &NonNull{Base: &List{Base: &NonNull{Base: inputValue}}},
-	})
+	fd := &FieldDef{Base: Base{N: argsStr}, Type: &NonNull{Base: &List{Base: &NonNull{Base: inputValue}}}}
+	_ = fd.args.add(&Arg{Base: Base{N: includeDeprecatedStr}, Type: root.types.get("Boolean"), Default: false})
+
+	_ = t.fields.add(fd)
 	_ = t.fields.add(&FieldDef{Base: Base{N: typeStr}, Type: &NonNull{Base: uuType}})
 	_ = t.fields.add(&FieldDef{Base: Base{N: isDeprecatedStr}, Type: &NonNull{Base: root.types.get(booleanStr)}})
 	_ = t.fields.add(&FieldDef{Base: Base{N: deprecationReasonStr}, Type: strType})
@@ -917,7 +1166,8 @@ func (root *Root) newUuEnumValue(strType Type) Type {
 //   name: String!
 //   description: String
 //   locations: [__DirectiveLocation!]!
-//   args: [__InputValue!]!
+//   args(includeDeprecated: Boolean = false): [__InputValue!]!
+//   isRepeatable: Boolean!
 // }.
 func (root *Root) newUuDirective(inputValue, strType Type) Type {
 	t := Object{
@@ -932,9 +1182,11 @@ func (root *Root) newUuDirective(inputValue, strType Type) Type {
 	_ = t.fields.add(&FieldDef{Base: Base{N: locationsStr},
 		Type: &NonNull{Base: &List{Base: &NonNull{Base: root.types.get("__DirectiveLocation")}}},
 	})
-	_ = t.fields.add(&FieldDef{Base: Base{N: argsStr},
-		Type: &NonNull{Base: &List{Base: &NonNull{Base: inputValue}}},
-	})
+	fd := &FieldDef{Base: Base{N: argsStr}, Type: &NonNull{Base: &List{Base: &NonNull{Base: inputValue}}}}
+	_ = fd.args.add(&Arg{Base: Base{N: includeDeprecatedStr}, Type: root.types.get("Boolean"), Default: false})
+
+	_ = t.fields.add(fd)
+	_ = t.fields.add(&FieldDef{Base: Base{N: isRepeatableStr}, Type: &NonNull{Base: root.types.get(booleanStr)}})
 	return &t
 }
 
@@ -966,14 +1218,14 @@ func (root *Root) newIncludeDirective() Type {
 	return &t
 }
 
-// directive @deprecated(reason: String = "No longer supported") on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT.
+// directive @deprecated(reason: String = "No longer supported") on FIELD_DEFINITION | ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION | ENUM_VALUE.
 func (root *Root) newDeprecatedDirective() Type {
 	t := Directive{
 		Base: Base{
 			N:    deprecatedStr,
 			core: true,
 		},
-		On: []Location{LocFieldDefinition, LocEnumValue},
+		On: []Location{LocFieldDefinition, LocArgumentDefinition, LocInputFieldDefinition, LocEnumValue},
 	}
 	// The Default value double quotes the value. Seems broken but GraphiQL
 	// expects it. Odd that it deviates from any other string value though.
@@ -996,6 +1248,60 @@ func (root *Root) newGoDirective() Type {
 	return &t
 }
 
+// directive @specifiedBy(url: String!) on SCALAR
+func (root *Root) newSpecifiedByDirective() Type {
+	t := Directive{
+		Base: Base{
+			N:    specifiedByStr,
+			core: true,
+		},
+		On: []Location{LocScalar},
+	}
+	_ = t.args.add(&Arg{Base: Base{N: urlStr}, Type: &NonNull{Base: root.types.get(stringStr)}})
+
+	return &t
+}
+
+// directive @oneOf on INPUT_OBJECT
+func (root *Root) newOneOfDirective() Type {
+	return &Directive{
+		Base: Base{
+			N:    oneOfStr,
+			core: true,
+		},
+		On: []Location{LocInputObject},
+	}
+}
+
+// directive @cacheControl(maxAge: Int, scope: CacheControlScope) on FIELD_DEFINITION | OBJECT | INTERFACE | UNION
+func (root *Root) newCacheControlDirective(scope Type) Type {
+	t := Directive{
+		Base: Base{
+			N:    cacheControlStr,
+			core: true,
+		},
+		On: []Location{LocFieldDefinition, LocObject, LocInterface, LocUnion},
+	}
+	_ = t.args.add(&Arg{Base: Base{N: maxAgeStr}, Type: root.types.get("Int")})
+	_ = t.args.add(&Arg{Base: Base{N: scopeStr}, Type: scope})
+
+	return &t
+}
+
+// enum CacheControlScope { PUBLIC PRIVATE }
+func (root *Root) newCacheControlScope() Type {
+	t := Enum{
+		Base: Base{
+			N:    cacheScopeStr,
+			core: true,
+		},
+	}
+	_ = t.values.add(&EnumValue{Value: Symbol(CachePublic)})
+	_ = t.values.add(&EnumValue{Value: Symbol(CachePrivate)})
+
+	return &t
+}
+
 func (root *Root) newTypeKind() Type {
 	t := Enum{
 		Base: Base{
@@ -1106,13 +1412,13 @@ func (root *Root) Unsubscribe(id string) (cnt int) {
 // for the subscription is used to form a result based on the type of event
 // being published.
 func (root *Root) AddEvent(id string, event interface{}) (cnt int, err error) {
-	vars := map[string]interface{}{}
+	ex := root.newExecution(map[string]interface{}{}, nil)
 	var ea []error
 	var failed []*Subscription
 	root.subLock.Lock()
 	for _, s := range root.subscriptions {
 		if s.sub.Match(id) {
-			result, ea2 := root.resolve(event, vars, s.field, s.field.ConType, MaxResolveDepth)
+			result, ea2 := root.resolve(event, ex, []*planField{s.plan}, s.field.ConType, ex.maxDepth)
 			ea = append(ea, ea2...)
 			cnt++
 			if err = s.sub.Send(result); err != nil {
@@ -80,7 +80,8 @@ type __Directive {
   name: String!
   description: String
   locations: [__DirectiveLocation!]!
-  args: [__InputValue!]!
+  args(includeDeprecated: Boolean = false): [__InputValue!]!
+  isRepeatable: Boolean!
 }
 
 type __EnumValue {
@@ -93,7 +94,7 @@ type __EnumValue {
 type __Field {
   name: String!
   description: String
-  args: [__InputValue!]!
+  args(includeDeprecated: Boolean = false): [__InputValue!]!
   type: __Type!
   isDeprecated: Boolean!
   deprecationReason: String
@@ -104,6 +105,8 @@ type __InputValue {
   description: String
   type: __Type!
   defaultValue: String
+  isDeprecated: Boolean!
+  deprecationReason: String
 }
 
 type __Type {
@@ -112,10 +115,11 @@ type __Type {
   description: String
   fields(includeDeprecated: Boolean = false): [__Field!]
   enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
-  inputFields: [__InputValue!]
+  inputFields(includeDeprecated: Boolean = false): [__InputValue!]
   interfaces: [__Type!]!
   possibleTypes: [__Type!]!
   ofType: __Type
+  specifiedByURL: String
 }
 
 enum __DirectiveLocation {
@@ -170,13 +174,17 @@ RFC3339 specification with nanoseconds.
 """
 scalar Time
 
-directive @deprecated(reason: String = "\"No longer supported\"") on FIELD_DEFINITION | ENUM_VALUE
+directive @deprecated(reason: String = "\"No longer supported\"") on FIELD_DEFINITION | ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION | ENUM_VALUE
 
 directive @go(type: String!) on SCHEMA | QUERY | MUTATION | SUBSCRIPTION | OBJECT | FIELD_DEFINITION
 
 directive @include(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
 
+directive @oneOf on INPUT_OBJECT
+
 directive @skip(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
+
+directive @specifiedBy(url: String!) on SCALAR
 `
 	checkEqual(t, expect, actual, "root SDL() mismatch")
 }
@@ -198,7 +206,8 @@ type __Directive {
   name: String!
   description: String
   locations: [__DirectiveLocation!]!
-  args: [__InputValue!]!
+  args(includeDeprecated: Boolean = false): [__InputValue!]!
+  isRepeatable: Boolean!
 }
 
 type __EnumValue {
@@ -211,7 +220,7 @@ type __EnumValue {
 type __Field {
   name: String!
   description: String
-  args: [__InputValue!]!
+  args(includeDeprecated: Boolean = false): [__InputValue!]!
   type: __Type!
   isDeprecated: Boolean!
   deprecationReason: String
@@ -222,6 +231,8 @@ type __InputValue {
   description: String
   type: __Type!
   defaultValue: String
+  isDeprecated: Boolean!
+  deprecationReason: String
 }
 
 type __Type {
@@ -230,10 +241,11 @@ type __Type {
   description: String
   fields(includeDeprecated: Boolean = false): [__Field!]
   enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
-  inputFields: [__InputValue!]
+  inputFields(includeDeprecated: Boolean = false): [__InputValue!]
   interfaces: [__Type!]!
   possibleTypes: [__Type!]!
   ofType: __Type
+  specifiedByURL: String
 }
 
 enum __DirectiveLocation {
@@ -280,13 +292,17 @@ scalar Int
 
 scalar String
 
-directive @deprecated(reason: String = "\"No longer supported\"") on FIELD_DEFINITION | ENUM_VALUE
+directive @deprecated(reason: String = "\"No longer supported\"") on FIELD_DEFINITION | ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION | ENUM_VALUE
 
 directive @go(type: String!) on SCHEMA | QUERY | MUTATION | SUBSCRIPTION | OBJECT | FIELD_DEFINITION
 
 directive @include(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
 
+directive @oneOf on INPUT_OBJECT
+
 directive @skip(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
+
+directive @specifiedBy(url: String!) on SCALAR
 `
 	checkEqual(t, expect, actual, "root SDL() mismatch")
 }
@@ -608,3 +624,19 @@ func TestRootReplaceRefsBadTop(t *testing.T) {
 	err = root.AddTypes(&ggql.Ref{Base: ggql.Base{N: "Ref"}})
 	checkNotNil(t, err, "check error was returned")
 }
+
+func TestRootSchema(t *testing.T) {
+	root := ggql.NewRoot(nil)
+	checkEqual(t, true, root.Schema() == nil, "no schema before parsing")
+
+	err := root.ParseString(`type Query { a: Int }
+enum Kind { BIG }`)
+	checkNil(t, err, "parse failed. %s", err)
+	schema := root.Schema()
+	checkNotNil(t, schema, "schema should be set after parsing")
+	checkEqual(t, "Query", schema.GetField("query").Type.Name(), "schema query type")
+
+	ev := root.GetType("Kind").(*ggql.Enum).Values()[0]
+	checkEqual(t, 2, ev.Line(), "enum value line")
+	checkEqual(t, 13, ev.Column(), "enum value column")
+}
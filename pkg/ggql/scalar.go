@@ -68,6 +68,7 @@ func (t *Scalar) Write(w io.Writer, desc bool) (err error) {
 //   enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
 //   inputfields: [__InputValue!]
 //   ofType: __Type
+//   specifiedByURL: String
 func (t *Scalar) Resolve(field *Field, args map[string]interface{}) (result interface{}, err error) {
 	switch field.Name {
 	case kindStr:
@@ -76,6 +77,12 @@ func (t *Scalar) Resolve(field *Field, args map[string]interface{}) (result inte
 		result = t.N
 	case descriptionStr:
 		result = t.Desc
+	case specifiedByURLStr:
+		if du := t.GetDirective(specifiedByStr); du != nil {
+			if av := du.Args[urlStr]; av != nil {
+				result = av.Value
+			}
+		}
 	case possibleTypesStr, fieldsStr, interfacesStr, enumValuesStr, inputFieldsStr, ofTypeStr:
 		// nil result
 	}
@@ -15,6 +15,7 @@
 package ggql_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/uhn/ggql/pkg/ggql"
@@ -47,3 +48,38 @@ scalar Scale @dirt
 	err := scalar.Write(w, false)
 	checkNotNil(t, err, "return error on write error")
 }
+
+func TestScalarSpecifiedBy(t *testing.T) {
+	ggql.Sort = true
+	root := ggql.NewRoot(&idSchema{Query: &idQuery{}})
+	err := root.ParseString(`
+type Query { id: UUID }
+scalar UUID @specifiedBy(url: "https://www.rfc-editor.org/rfc/rfc4122")
+`)
+	checkNil(t, err, "no error should be returned when parsing a valid SDL. %s", err)
+
+	result := root.ResolveString(`{
+  u: __type(name: "UUID") { specifiedByURL }
+  s: __type(name: "String") { specifiedByURL }
+  q: __type(name: "Query") { specifiedByURL }
+}`, "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, 2)
+	checkEqual(t, `{
+  "data": {
+    "q": {
+      "specifiedByURL": null
+    },
+    "s": {
+      "specifiedByURL": null
+    },
+    "u": {
+      "specifiedByURL": "https://www.rfc-editor.org/rfc/rfc4122"
+    }
+  }
+}
+`, b.String(), "result should match")
+
+	err = root.ParseString(`type Extra @specifiedBy(url: "x") { id: ID }`)
+	checkNotNil(t, err, "@specifiedBy should only be allowed on scalars")
+}
@@ -113,6 +113,10 @@ func (p *sdlParser) readDirective(desc string) (Type, error) {
 	if err == nil {
 		token, err = p.readToken()
 	}
+	if err == nil && token == repeatableStr {
+		dir.Repeatable = true
+		token, err = p.readToken()
+	}
 	if err == nil && token != "on" {
 		err = fmt.Errorf("%w, directives must have an 'on' keyword at %d:%d", ErrParse, p.line, p.col)
 	}
@@ -141,13 +145,13 @@ func (p *sdlParser) readDirective(desc string) (Type, error) {
 
 // https://graphql.github.io/graphql-spec/June2018/#sec-Enums
 func (p *sdlParser) readEnum(desc string) (Type, error) {
-	token, err := p.readToken()
+	token, line, col, err := p.readTokenAt()
 	if err == nil && len(token) == 0 {
 		err = fmt.Errorf("%w, no enum name provided at %d:%d", ErrParse, p.line, p.col)
 	}
 	var enum *Enum
 	if err == nil {
-		enum = &Enum{Base: Base{N: token, Desc: desc, line: p.line, col: p.col - len(token) - 1}}
+		enum = &Enum{Base: Base{N: token, Desc: desc, line: line, col: col}}
 		enum.Dirs, err = p.readDirUses()
 	}
 	var b byte
@@ -184,15 +188,16 @@ func (p *sdlParser) readEnum(desc string) (Type, error) {
 func (p *sdlParser) readEnumValue() (ev *EnumValue, err error) {
 	var desc string
 	var token string
+	var line, col int
 
 	if desc, err = p.readDesc(); err == nil {
-		token, err = p.readToken()
+		token, line, col, err = p.readTokenAt()
 	}
 	if err == nil && len(token) == 0 {
 		err = fmt.Errorf("%w, invalid enum value name at %d:%d", ErrParse, p.line, p.col)
 	}
 	if err == nil {
-		ev = &EnumValue{Value: Symbol(token), Description: desc, line: p.line, col: p.col - len(token) - 1}
+		ev = &EnumValue{Value: Symbol(token), Description: desc, line: line, col: col}
 		var du *DirectiveUse
 		for {
 			if du, err = p.readDirUse(); du == nil {
@@ -206,7 +211,7 @@ func (p *sdlParser) readEnumValue() (ev *EnumValue, err error) {
 
 // https://graphql.github.io/graphql-spec/June2018/#sec-Input-Objects
 func (p *sdlParser) readInput(desc string) (Type, error) {
-	token, err := p.readToken()
+	token, line, col, err := p.readTokenAt()
 	if err == nil && len(token) == 0 {
 		err = fmt.Errorf("%w, no input name provided at %d:%d", ErrParse, p.line, p.col)
 	}
@@ -216,8 +221,8 @@ func (p *sdlParser) readInput(desc string) (Type, error) {
 			Base: Base{
 				N:    token,
 				Desc: desc,
-				line: p.line,
-				col:  p.col - len(token) - 1,
+				line: line,
+				col:  col,
 			},
 			fields: inputFieldList{
 				dict: map[string]*InputField{},
@@ -244,7 +249,7 @@ func (p *sdlParser) readInput(desc string) (Type, error) {
 
 // https://graphql.github.io/graphql-spec/June2018/#sec-Interfaces
 func (p *sdlParser) readInterface(desc string) (Type, error) {
-	token, err := p.readToken()
+	token, line, col, err := p.readTokenAt()
 	if err == nil && len(token) == 0 {
 		err = fmt.Errorf("%w, no interface name provided at %d:%d", ErrParse, p.line, p.col)
 	}
@@ -254,14 +259,17 @@ func (p *sdlParser) readInterface(desc string) (Type, error) {
 			Base: Base{
 				N:    token,
 				Desc: desc,
-				line: p.line,
-				col:  p.col - len(token) - 1,
+				line: line,
+				col:  col,
 			},
 			fields: fieldList{
 				dict: map[string]*FieldDef{},
 			},
 			Root: p.root,
 		}
+		inf.Interfaces, err = p.readImplements()
+	}
+	if err == nil {
 		inf.Dirs, err = p.readDirUses()
 	}
 	var b byte
@@ -280,7 +288,7 @@ func (p *sdlParser) readInterface(desc string) (Type, error) {
 
 // https://graphql.github.io/graphql-spec/June2018/#sec-Scalars
 func (p *sdlParser) readScalar(desc string) (Type, error) {
-	token, err := p.readToken()
+	token, line, col, err := p.readTokenAt()
 	if err == nil && len(token) == 0 {
 		err = fmt.Errorf("%w, no scalar name provided at %d:%d", ErrParse, p.line, p.col)
 	}
@@ -291,8 +299,8 @@ func (p *sdlParser) readScalar(desc string) (Type, error) {
 				Base: Base{
 					N:    token,
 					Desc: desc,
-					line: p.line,
-					col:  p.col - len(token),
+					line: line,
+					col:  col,
 				},
 			},
 		}
@@ -339,7 +347,7 @@ func (p *sdlParser) readSchema(extend bool) (Type, error) {
 
 // https://graphql.github.io/graphql-spec/June2018/#sec-Objects
 func (p *sdlParser) readObject(desc string) (Type, error) {
-	token, err := p.readToken()
+	token, line, col, err := p.readTokenAt()
 	if err == nil && len(token) == 0 {
 		err = fmt.Errorf("%w, no type name provided at %d:%d", ErrParse, p.line, p.col)
 	}
@@ -349,8 +357,8 @@ func (p *sdlParser) readObject(desc string) (Type, error) {
 			Base: Base{
 				N:    token,
 				Desc: desc,
-				line: p.line,
-				col:  p.col - len(token) - 1,
+				line: line,
+				col:  col,
 			},
 			fields: fieldList{
 				dict: map[string]*FieldDef{},
@@ -377,13 +385,13 @@ func (p *sdlParser) readObject(desc string) (Type, error) {
 
 // https://graphql.github.io/graphql-spec/June2018/#sec-Unions
 func (p *sdlParser) readUnion(desc string) (Type, error) {
-	token, err := p.readToken()
+	token, line, col, err := p.readTokenAt()
 	if err == nil && len(token) == 0 {
 		err = fmt.Errorf("%w, no union name provided at %d:%d", ErrParse, p.line, p.col)
 	}
 	var union *Union
 	if err == nil {
-		union = &Union{Base: Base{N: token, Desc: desc, line: p.line, col: p.col - len(token) - 1}}
+		union = &Union{Base: Base{N: token, Desc: desc, line: line, col: col}}
 		union.Dirs, err = p.readDirUses()
 	}
 	var b byte
@@ -453,10 +461,11 @@ func (p *sdlParser) readArg() (arg *Arg, err error) {
 		return
 	}
 	var token string
-	if token, err = p.readToken(); err != nil {
+	var line, col int
+	if token, line, col, err = p.readTokenAt(); err != nil {
 		return
 	}
-	arg = &Arg{Base: Base{N: token, Desc: desc, line: p.line, col: p.col - len(token) - 1}}
+	arg = &Arg{Base: Base{N: token, Desc: desc, line: line, col: col}}
 
 	var b byte
 	if b, err = p.skipSpace(); err != nil {
@@ -533,12 +542,13 @@ func (p *sdlParser) readFields(fields *fieldList) (err error) {
 func (p *sdlParser) readField() (f *FieldDef, err error) {
 	var desc string
 	var token string
+	var line, col int
 	var b byte
 
 	if desc, err = p.readDesc(); err == nil {
-		token, err = p.readToken()
+		token, line, col, err = p.readTokenAt()
 	}
-	f = &FieldDef{Base: Base{N: token, Desc: desc, line: p.line, col: p.col - len(token) - 1}}
+	f = &FieldDef{Base: Base{N: token, Desc: desc, line: line, col: col}}
 	if err == nil {
 		b, err = p.skipSpace()
 	}
@@ -610,10 +620,11 @@ func (p *sdlParser) readInputFields(fields *inputFieldList) (err error) {
 func (p *sdlParser) readInputField() (f *InputField, err error) {
 	var desc string
 	var token string
+	var line, col int
 	var b byte
 
 	if desc, err = p.readDesc(); err == nil {
-		token, err = p.readToken()
+		token, line, col, err = p.readTokenAt()
 	}
 	if err == nil {
 		b, err = p.skipSpace()
@@ -621,7 +632,7 @@ func (p *sdlParser) readInputField() (f *InputField, err error) {
 	if b == 0 {
 		return nil, nil
 	}
-	f = &InputField{Base: Base{N: token, Desc: desc, line: p.line, col: p.col - len(token) - 1}}
+	f = &InputField{Base: Base{N: token, Desc: desc, line: line, col: col}}
 	if err == nil && b != ':' {
 		err = fmt.Errorf("%w, field name not followed by a ':' at %d:%d", ErrParse, p.line, p.col)
 	}
@@ -519,3 +519,20 @@ func TestRootParseFSNoPatterns(t *testing.T) {
 	err := root.ParseFS(os.DirFS(t.TempDir()))
 	checkNil(t, err, "no error should be returned")
 }
+
+func TestSDLParseLocations(t *testing.T) {
+	root := ggql.NewRoot(nil)
+	err := root.ParseString(`type Query { a: Date }
+scalar Date
+enum Kind {
+  BIG
+}`)
+	checkNil(t, err, "parse failed. %s", err)
+
+	st := root.GetType("Date")
+	checkEqual(t, 2, st.Line(), "scalar line")
+	checkEqual(t, 8, st.Column(), "scalar column")
+	ev := root.GetType("Kind").(*ggql.Enum).Values()[0]
+	checkEqual(t, 4, ev.Line(), "enum value line")
+	checkEqual(t, 3, ev.Column(), "enum value column")
+}
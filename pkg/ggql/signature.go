@@ -0,0 +1,184 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// SignatureOptions are the options for Op.Signature().
+type SignatureOptions struct {
+
+	// DropAliases removes field aliases from the signature so that
+	// operations that only differ by aliases have the same signature.
+	DropAliases bool
+}
+
+// Signature returns a canonical form of the operation along with the
+// fragments it uses and the hex encoded SHA-256 hash of that form.
+// Operations that differ only in literal argument values, field and
+// fragment order, formatting, unused fragments, and optionally aliases
+// have the same signature. String literals are replaced by "", numbers by
+// 0, lists by [], and input objects by {}. Variables, booleans, nulls, and
+// enum values are kept. Selections, arguments, variables, directives, and
+// fragments are sorted.
+func (op *Op) Signature(opts *SignatureOptions) (sig string, hash string) {
+	if opts == nil {
+		opts = &SignatureOptions{}
+	}
+	frags := map[string]*Fragment{}
+	var b strings.Builder
+
+	b.WriteString(string(op.Type))
+	if 0 < len(op.Name) {
+		b.WriteByte(' ')
+		b.WriteString(op.Name)
+	}
+	if 0 < len(op.Variables) {
+		vars := make([]string, 0, len(op.Variables))
+		for _, v := range op.Variables {
+			s := "$" + v.Name + ": " + v.Type.Name()
+			if v.Default != nil {
+				s += " = " + sigValue(v.Default)
+			}
+			vars = append(vars, s+sigDirectives(v.Dirs))
+		}
+		sort.Strings(vars)
+		b.WriteString("(" + strings.Join(vars, ", ") + ")")
+	}
+	b.WriteString(sigDirectives(op.Dirs))
+	b.WriteString(sigSels(op.Sels, opts, frags))
+
+	// Fragments can use other fragments so keep going until no more are
+	// added.
+	done := map[string]string{}
+	for len(done) < len(frags) {
+		for name, f := range frags {
+			if _, has := done[name]; has {
+				continue
+			}
+			s := "fragment " + name
+			if f.Condition != nil {
+				s += " on " + f.Condition.Name()
+			}
+			done[name] = s + sigDirectives(f.Dirs) + sigSels(f.Sels, opts, frags)
+		}
+	}
+	names := make([]string, 0, len(done))
+	for name := range done {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteByte(' ')
+		b.WriteString(done[name])
+	}
+	sig = b.String()
+	sum := sha256.Sum256([]byte(sig))
+
+	return sig, hex.EncodeToString(sum[:])
+}
+
+func sigSels(sels []Selection, opts *SignatureOptions, frags map[string]*Fragment) string {
+	if len(sels) == 0 {
+		return ""
+	}
+	list := make([]string, 0, len(sels))
+	for _, sel := range sels {
+		var s string
+		switch ts := sel.(type) {
+		case *Field:
+			if 0 < len(ts.Alias) && !opts.DropAliases {
+				s = ts.Alias + ": "
+			}
+			s += ts.Name
+			if 0 < len(ts.Args) {
+				args := make([]string, 0, len(ts.Args))
+				for _, av := range ts.Args {
+					if av != nil {
+						args = append(args, av.Arg+": "+sigValue(av.Value))
+					}
+				}
+				if 0 < len(args) {
+					sort.Strings(args)
+					s += "(" + strings.Join(args, ", ") + ")"
+				}
+			}
+			s += sigDirectives(ts.Dirs) + sigSels(ts.Sels, opts, frags)
+		case *FragRef:
+			s = spreadStr + ts.Fragment.Name + sigDirectives(ts.Dirs)
+			frags[ts.Fragment.Name] = ts.Fragment
+		case *Inline:
+			s = spreadStr
+			if ts.Condition != nil {
+				s += " on " + ts.Condition.Name()
+			}
+			s += sigDirectives(ts.Dirs) + sigSels(ts.Sels, opts, frags)
+		}
+		list = append(list, s)
+	}
+	sort.Strings(list)
+
+	return " {" + strings.Join(list, " ") + "}"
+}
+
+func sigDirectives(dirs []*DirectiveUse) string {
+	if len(dirs) == 0 {
+		return ""
+	}
+	list := make([]string, 0, len(dirs))
+	for _, du := range dirs {
+		s := "@" + du.Directive.Name()
+		if 0 < len(du.Args) {
+			args := make([]string, 0, len(du.Args))
+			for _, av := range du.Args {
+				args = append(args, av.Arg+": "+sigValue(av.Value))
+			}
+			sort.Strings(args)
+			s += "(" + strings.Join(args, ", ") + ")"
+		}
+		list = append(list, s)
+	}
+	sort.Strings(list)
+
+	return " " + strings.Join(list, " ")
+}
+
+// sigValue returns the placeholder for a value.
+func sigValue(v interface{}) string {
+	switch tv := v.(type) {
+	case nil:
+		return nullStr
+	case Var:
+		return "$" + string(tv)
+	case Symbol:
+		return string(tv)
+	case bool:
+		if tv {
+			return trueStr
+		}
+		return falseStr
+	case string:
+		return `""`
+	case []interface{}:
+		return "[]"
+	case map[string]interface{}:
+		return "{}"
+	}
+	return "0"
+}
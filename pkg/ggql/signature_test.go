@@ -0,0 +1,62 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql_test
+
+import (
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+func TestOpSignature(t *testing.T) {
+	root := setupSchema(t)
+
+	exe, err := root.ParseExecutableString(`
+query Songs($name: String! = "Fazerdaze", $skip: Boolean) {
+  title
+  a: artist(name: $name) { ...where songs @skip(if: $skip) { duration name } }
+  artist(name: "Frazerdaze") { ... on Artist { name } }
+}
+fragment where on Artist { origin ...named }
+fragment named on Artist { name }
+fragment unused on Song { name }
+`)
+	checkNil(t, err, "parse failed. %s", err)
+	op := exe.Ops["Songs"]
+
+	sig, hash := op.Signature(nil)
+	checkEqual(t, `query Songs($name: String! = "", $skip: Boolean) {a: artist(name: $name) {...where songs @skip(if: $skip) {duration name}} artist(name: "") {... on Artist {name}} title} fragment named on Artist {name} fragment where on Artist {...named origin}`,
+		sig, "signature mismatch")
+	checkEqual(t, 64, len(hash), "hash length")
+
+	// Reordered with different literals and formatting.
+	exe, err = root.ParseExecutableString(`
+fragment named on Artist { name }
+fragment where on Artist { ...named, origin }
+query Songs($skip: Boolean, $name: String! = "Other") {
+  artist(name: "Someone") { ... on Artist { name } }
+  a: artist(name: $name) { songs @skip(if: $skip) { name duration } ...where }
+  title
+}
+`)
+	checkNil(t, err, "parse failed. %s", err)
+	sig2, hash2 := exe.Ops["Songs"].Signature(nil)
+	checkEqual(t, sig, sig2, "equivalent signatures should match")
+	checkEqual(t, hash, hash2, "equivalent hashes should match")
+
+	sig, _ = op.Signature(&ggql.SignatureOptions{DropAliases: true})
+	checkEqual(t, `query Songs($name: String! = "", $skip: Boolean) {artist(name: "") {... on Artist {name}} artist(name: $name) {...where songs @skip(if: $skip) {duration name}} title} fragment named on Artist {name} fragment where on Artist {...named origin}`,
+		sig, "signature without aliases mismatch")
+}
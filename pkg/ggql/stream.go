@@ -0,0 +1,434 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+)
+
+// StreamPlan evaluates a compiled Plan and writes the JSON response to w as
+// each field is resolved instead of first building the complete result.
+// Fields are written in the order of the selection set with fields of the
+// same response name merged and the errors, if any, are written last. The
+// returned error is only for failures writing to w, GraphQL errors are part
+// of the response.
+func (root *Root) StreamPlan(w io.Writer, plan *Plan, vars map[string]interface{}) error {
+	return root.StreamPlanOptions(w, plan, vars, nil)
+}
+
+// StreamPlanOptions writes the JSON response for a compiled Plan in the
+// same way as StreamPlan but with the options provided instead of the
+// options of the root. The Ordered and Sort options do not apply as
+// streamed responses are always in selection set order.
+func (root *Root) StreamPlanOptions(w io.Writer, plan *Plan, vars map[string]interface{}, opts *Options) error {
+	sw := newStreamWriter(w)
+	root.streamPlan(sw, plan, vars, opts)
+
+	return sw.flush()
+}
+
+// StreamBytes parses an SDL executable []byte, evaluates it, and writes the
+// JSON response to w.
+func (root *Root) StreamBytes(w io.Writer, src []byte, op string, vars map[string]interface{}) error {
+	return root.StreamReader(w, bytes.NewReader(src), op, vars)
+}
+
+// StreamString parses an SDL executable string, evaluates it, and writes
+// the JSON response to w.
+func (root *Root) StreamString(w io.Writer, src string, op string, vars map[string]interface{}) error {
+	return root.StreamReader(w, strings.NewReader(src), op, vars)
+}
+
+// StreamReader parses an SDL reader, evaluates it, and writes the JSON
+// response to w in the same way as StreamPlan. The executable cache is used
+// in the same way as ResolveReader.
+func (root *Root) StreamReader(w io.Writer, r io.Reader, op string, vars map[string]interface{}) error {
+	sw := newStreamWriter(w)
+	if plan, parsed, err := root.cachedPlan(r, op); err == nil {
+		root.streamPlan(sw, plan, vars, nil)
+	} else {
+		sw.beginObject()
+		if parsed {
+			sw.key("data")
+			sw.raw(nullStr)
+		}
+		sw.errors(root.FormErrors(err))
+		sw.endObject()
+	}
+	return sw.flush()
+}
+
+func (root *Root) streamPlan(sw *streamWriter, plan *Plan, vars map[string]interface{}, opts *Options) {
+	op := plan.op
+	if op.Type == OpSubscription {
+		// Subscriptions return no data so there is nothing to stream.
+		_, err := root.ResolvePlanOptions(plan, vars, opts)
+		sw.beginObject()
+		sw.key("data")
+		sw.raw(nullStr)
+		if err != nil {
+			sw.errors(root.FormErrors(err))
+		}
+		sw.endObject()
+		return
+	}
+	sw.beginObject()
+	ex := root.newExecution(nil, opts)
+	var err error
+	if ex.vars, err = root.coerceVars(op, vars, ex.opts.Relaxed); err != nil {
+		sw.key("data")
+		sw.raw(nullStr)
+		sw.errors(root.FormErrors(err))
+		sw.endObject()
+		return
+	}
+	ea := root.streamField(sw, root.obj, ex, []*planField{plan.top}, ex.maxDepth)
+	if root.DeprecationWarnings {
+		if warns := root.deprecationWarnings(op, vars); 0 < len(warns) {
+			sw.key("extensions")
+			sw.value(map[string]interface{}{"warnings": FormErrorsResult(Errors(warns))})
+		}
+	}
+	if 0 < len(ea) {
+		sw.errors(root.FormErrors(Errors(ea)))
+	}
+	sw.endObject()
+}
+
+// streamField writes the member for a field with the value resolved on obj.
+// All the fields in pfs have the same response name and the selections of
+// all of them are merged. The value is resolved using the first field.
+func (root *Root) streamField(
+	sw *streamWriter,
+	obj interface{},
+	ex *execution,
+	pfs []*planField,
+	depth int) (ea []error) {
+
+	pf := pfs[0]
+	if 0 < len(pf.fails) {
+		return freshErrors(pf.fails)
+	}
+	field := pf.field
+	// If a panic occurs the output is repaired so that the field value is
+	// null or what was written so far is closed.
+	mark := len(sw.stack)
+	keyed := false
+	defer func() {
+		if r := recover(); r != nil {
+			if keyed {
+				sw.repair(mark)
+			} else {
+				sw.key(field.key())
+				sw.raw(nullStr)
+			}
+			ea = append(ea, root.panicError(field, r))
+			if depth < ex.maxDepth {
+				Errors(ea).in(field.key())
+			}
+		}
+	}()
+	t := field.ConType
+	var ea2 []error
+	switch pf.meta {
+	case "__typename":
+		sw.key(field.key())
+		keyed = true
+		sw.str(root.typename(obj, t))
+		return nil
+	case "__type":
+		if t.Name() == queryTypeStr {
+			nt, ea2 := root.typeArg(ex, pf)
+			ea = append(ea, ea2...)
+			sw.key(field.key())
+			keyed = true
+			if nt != nil {
+				ea2 = root.streamValue(sw, nt, ex, pfs, pf.ft, depth)
+				ea = append(ea, ea2...)
+				Errors(ea).in(field.key())
+			} else {
+				sw.raw(nullStr)
+			}
+			return
+		}
+		return []error{resWarnp(field, "__type meta-field is only on the query object")}
+	case "__schema":
+		if t.Name() == queryTypeStr {
+			sw.key(field.key())
+			keyed = true
+			ea = root.streamValue(sw, root, ex, pfs, pf.ft, depth)
+			Errors(ea).in(field.key())
+			return
+		}
+		return []error{resWarnp(field, "__schema meta-field is only on the query object")}
+	}
+	attr, ea := root.fieldValue(obj, ex, pf)
+	sw.key(field.key())
+	keyed = true
+	if IsNil(attr) {
+		sw.raw(nullStr)
+	} else {
+		ea2 = root.streamValue(sw, attr, ex, pfs, pf.ft, depth)
+		ea = append(ea, ea2...)
+	}
+	if depth < ex.maxDepth && 0 < len(ea) {
+		Errors(ea).in(field.key())
+	}
+	return
+}
+
+// streamValue writes obj as a value of type t.
+func (root *Root) streamValue(
+	sw *streamWriter,
+	obj interface{},
+	ex *execution,
+	pfs []*planField,
+	t Type,
+	depth int) (ea []error) {
+
+	if depth <= 0 || IsNil(obj) {
+		sw.value(obj)
+		return nil
+	}
+	switch tt := t.(type) {
+	case *List:
+		// The opening bracket is not written until it is known that obj is
+		// a list.
+		started := false
+		ea, ok := root.listEach(obj, func(i int, v interface{}, raw bool) (ea2 []error) {
+			if sw.err != nil {
+				return nil
+			}
+			if !started {
+				sw.beginList()
+				started = true
+			}
+			sw.elem()
+			if raw {
+				sw.value(v)
+				return nil
+			}
+			ea2 = root.streamValue(sw, v, ex, pfs, tt.Base, depth-1)
+			Errors(ea2).in(i)
+			return
+		})
+		if !ok {
+			sw.raw(nullStr)
+			return append(ea, resWarn(pfs[0].field.line, pfs[0].field.col, "%T is not a list type", obj))
+		}
+		if !started {
+			sw.beginList()
+		}
+		sw.endList()
+		return ea
+	case *Object, *Schema, *Interface, *uuSchema:
+		ea = root.streamObject(sw, obj, ex, pfs, t, depth-1)
+	case *NonNull:
+		ea = root.streamValue(sw, obj, ex, pfs, tt.Base, depth)
+	case *Union:
+		m, err := root.unionMember(obj, tt)
+		switch {
+		case err != nil:
+			sw.raw(nullStr)
+			ea = []error{err}
+		case m == nil:
+			sw.raw("{}")
+		default:
+			ea = root.streamObject(sw, obj, ex, pfs, m, depth-1)
+		}
+	default:
+		var result interface{}
+		if co, _ := tt.(OutCoercer); co != nil {
+			var err error
+			if result, err = co.CoerceOut(obj); err != nil {
+				ea = append(ea, resWarn(pfs[0].field.line, pfs[0].field.col, "%s", err))
+			}
+		}
+		sw.value(result)
+	}
+	return
+}
+
+// streamObject writes obj as an object with the merged selections of pfs.
+func (root *Root) streamObject(
+	sw *streamWriter,
+	obj interface{},
+	ex *execution,
+	pfs []*planField,
+	t Type,
+	depth int) (ea []error) {
+
+	sw.beginObject()
+	set := pfs[0].set(root, t)
+	if set.leaf {
+		sw.endObject()
+		return []error{resWarnp(nil, "%s is not a valid output leaf type", set.t.Name())}
+	}
+	if len(pfs) == 1 && set.flat {
+		// No fragments, conditions, or duplicate response names so the
+		// fields can be written directly.
+		for i := range set.sels {
+			if sw.err != nil {
+				break
+			}
+			ea = append(ea, root.streamField(sw, obj, ex, set.sels[i].fields, depth)...)
+		}
+		sw.endObject()
+		return
+	}
+	var fields []memberFields
+	index := map[string]int{}
+	for _, pf := range pfs {
+		fields, ea = root.collectFields(obj, ex, pf.set(root, t), nil, index, fields, ea)
+	}
+	for _, mf := range fields {
+		if sw.err != nil {
+			break
+		}
+		ea2 := root.streamField(sw, obj, ex, mf.fields, depth)
+		for i := len(mf.frags) - 1; 0 <= i; i-- {
+			Errors(ea2).in(mf.frags[i])
+		}
+		ea = append(ea, ea2...)
+	}
+	sw.endObject()
+	return
+}
+
+// streamWriter writes compact JSON to a buffered writer keeping track of
+// the need for separators. Once a write fails nothing more is written and
+// the error is returned on flush.
+type streamWriter struct {
+	w       *bufio.Writer
+	err     error
+	stack   []streamFrame
+	pending bool // a key or separator was written and a value is expected
+}
+
+// streamFrame is an open object or list.
+type streamFrame struct {
+	end  string
+	more bool // true if a member has been written
+}
+
+func newStreamWriter(w io.Writer) *streamWriter {
+	return &streamWriter{w: bufio.NewWriter(w), stack: make([]streamFrame, 0, 16)}
+}
+
+func (sw *streamWriter) raw(s string) {
+	sw.pending = false
+	if sw.err == nil {
+		_, sw.err = sw.w.WriteString(s)
+	}
+}
+
+func (sw *streamWriter) value(v interface{}) {
+	if s, ok := v.(string); ok {
+		sw.str(s)
+		return
+	}
+	sw.pending = false
+	if sw.err == nil {
+		sw.err = writeValue(sw.w, v, false, 0, -1)
+	}
+}
+
+// str writes a JSON string, directly if no characters need to be escaped.
+func (sw *streamWriter) str(s string) {
+	sw.pending = false
+	if sw.err != nil {
+		return
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < ' ' || c == '"' || c == '\\' || 0x80 <= c {
+			sw.err = writeString(sw.w, s, true)
+			return
+		}
+	}
+	sw.raw(`"`)
+	sw.raw(s)
+	sw.raw(`"`)
+}
+
+// errors writes the errors member if there are any errors.
+func (sw *streamWriter) errors(list []interface{}) {
+	if 0 < len(list) {
+		sw.key("errors")
+		sw.value(list)
+	}
+}
+
+func (sw *streamWriter) elem() {
+	if n := len(sw.stack) - 1; 0 <= n {
+		if sw.stack[n].more {
+			sw.raw(",")
+		}
+		sw.stack[n].more = true
+		sw.pending = true
+	}
+}
+
+func (sw *streamWriter) key(k string) {
+	sw.elem()
+	sw.raw(`"`)
+	sw.raw(k)
+	sw.raw(`":`)
+	sw.pending = true
+}
+
+func (sw *streamWriter) beginObject() {
+	sw.raw("{")
+	sw.stack = append(sw.stack, streamFrame{end: "}"})
+}
+
+func (sw *streamWriter) endObject() {
+	sw.stack = sw.stack[:len(sw.stack)-1]
+	sw.raw("}")
+}
+
+func (sw *streamWriter) beginList() {
+	sw.raw("[")
+	sw.stack = append(sw.stack, streamFrame{end: "]"})
+}
+
+func (sw *streamWriter) endList() {
+	sw.stack = sw.stack[:len(sw.stack)-1]
+	sw.raw("]")
+}
+
+// repair completes a value that was interrupted by a panic by writing null
+// if a value is expected and closing any objects and lists opened since
+// the stack depth was depth.
+func (sw *streamWriter) repair(depth int) {
+	if sw.pending {
+		sw.raw(nullStr)
+	}
+	for depth < len(sw.stack) {
+		n := len(sw.stack) - 1
+		end := sw.stack[n].end
+		sw.stack = sw.stack[:n]
+		sw.raw(end)
+	}
+}
+
+func (sw *streamWriter) flush() error {
+	if sw.err == nil {
+		sw.err = sw.w.Flush()
+	}
+	return sw.err
+}
@@ -0,0 +1,113 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+func TestStreamMatchesResolve(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	for _, src := range []string{
+		`{artists{name songs{name duration release}}}`,
+		`{artist(name:"Fazerdaze"){name origin ...on Artist{songs{name}}}}`,
+		`{all{__typename ...on Artist{name} ...on Song{name duration}}}`,
+		`{title __typename __type(name:"Artist"){name fields{name}}}`,
+		`{artist(name:"Fazerdaze"){...A}} fragment A on Artist {name songs{name}}`,
+		`query($name: String!){artist(name:$name){name}}`,
+		`{artists{name`,
+		`mutation {like(artist:"Fazerdaze" song:"Reel"){name}}`,
+	} {
+		var b strings.Builder
+		_ = ggql.WriteJSONValue(&b, root.ResolveString(src, "", nil), -1)
+		expect := b.String()
+
+		var sb strings.Builder
+		err := root.StreamString(&sb, src, "", nil)
+		checkNil(t, err, "stream failed for %s. %s", src, err)
+		var v interface{}
+		err = json.Unmarshal([]byte(sb.String()), &v)
+		checkNil(t, err, "streamed JSON for %s is not valid. %s\n%s", src, err, sb.String())
+		b.Reset()
+		_ = ggql.WriteJSONValue(&b, v, -1)
+		checkEqual(t, expect, b.String(), "stream and resolve mismatch for %s", src)
+	}
+}
+
+func TestStreamOrder(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	var b strings.Builder
+	err := root.StreamString(&b, `{artist(name:"Fazerdaze"){origin name}}`, "", nil)
+	checkNil(t, err, "stream failed. %s", err)
+	checkEqual(t, `{"data":{"artist":{"origin":["Morningside","Auckland","New Zealand"],"name":"Fazerdaze"}}}`,
+		b.String(), "stream should follow the selection order")
+}
+
+func TestStreamMerge(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	src := `{artist(name:"Fazerdaze"){songs{name} name ...on Artist{songs{duration} name}}}`
+	var b strings.Builder
+	err := root.StreamString(&b, src, "", nil)
+	checkNil(t, err, "stream failed. %s", err)
+	checkEqual(t, `{"data":{"artist":{"songs":[{"name":"Jennifer","duration":240},{"name":"Lucky Girl","duration":170},`+
+		`{"name":"Friends","duration":194},{"name":"Reel","duration":193}],"name":"Fazerdaze"}}}`,
+		b.String(), "fields with the same response name should be merged")
+}
+
+func TestStreamErrors(t *testing.T) {
+	root := setupTestSongs(t, &strings.Builder{})
+	var b strings.Builder
+	err := root.StreamString(&b, `{artist(name:""){name} title}`, "", nil)
+	checkNil(t, err, "stream failed. %s", err)
+	checkEqual(t, `{"data":{"artist":null,"title":"Songs"},"errors":[{"locations":[{"column":3,"line":1}],`+
+		`"message":"resolve error: name argument not provided to field artist","path":["artist"]}]}`,
+		b.String(), "errors should be written after the data")
+}
+
+func TestStreamPlan(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	exe, err := root.ParseExecutableString(`query find($name: String!){artist(name: $name){name}}`)
+	checkNil(t, err, "parse failed. %s", err)
+	plan, err := root.Compile(exe, "find")
+	checkNil(t, err, "compile failed. %s", err)
+
+	var b strings.Builder
+	err = root.StreamPlan(&b, plan, map[string]interface{}{"name": "Viagra Boys"})
+	checkNil(t, err, "stream failed. %s", err)
+	checkEqual(t, `{"data":{"artist":{"name":"Viagra Boys"}}}`, b.String(), "stream plan result")
+
+	b.Reset()
+	err = root.StreamPlan(&b, plan, map[string]interface{}{"name": "Fazerdaze"})
+	checkNil(t, err, "stream failed. %s", err)
+	checkEqual(t, `{"data":{"artist":{"name":"Fazerdaze"}}}`, b.String(), "stream plan result")
+}
+
+func TestStreamWriteError(t *testing.T) {
+	root := setupTestReflectSongs(t)
+	src := `{artists{name songs{name duration}}}`
+	for _, size := range []int{0, 10, 4096} {
+		w := &failWriter{max: size}
+		err := root.StreamString(w, src, "", nil)
+		if size < 4096 {
+			checkNotNil(t, err, "stream to a failing writer should fail")
+		} else {
+			checkNil(t, err, "stream failed. %s", err)
+		}
+	}
+}
@@ -19,6 +19,7 @@ type Subscription struct {
 	sub   Subscriber
 	field *Field
 	args  map[string]interface{}
+	plan  *planField
 }
 
 // NewSubscription creates a new subscription. It should be called in a
@@ -32,5 +33,12 @@ func NewSubscription(sub Subscriber, field *Field, args map[string]interface{})
 }
 
 func (sub *Subscription) prep(root *Root) {
-	sub.field.ConType = root.getFieldType(sub.field.ConType, sub.field.Name)
+	// The field passed to the resolver is part of a Plan so a copy is
+	// modified instead.
+	field := *sub.field
+	field.ConType = root.getFieldType(field.ConType, field.Name)
+	sub.field = &field
+	sub.plan = &planField{field: sub.field, ft: field.ConType}
+	c := compiler{root: root, active: map[*Fragment]bool{}}
+	c.sets(sub.plan)
 }
@@ -0,0 +1,102 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	graphqlTag = "graphql"
+	jsonTag    = "json"
+)
+
+// tagName returns the name from a struct field tag along with an indication
+// of whether the field should be hidden. The graphql tag takes precedence
+// over the json tag which is only checked if withJSON is true. Options after
+// a comma such as omitempty are ignored.
+func tagName(sf reflect.StructField, withJSON bool) (name string, tagged bool) {
+	keys := []string{graphqlTag}
+	if withJSON {
+		keys = append(keys, jsonTag)
+	}
+	for _, key := range keys {
+		if tag, ok := sf.Tag.Lookup(key); ok {
+			if i := strings.IndexByte(tag, ','); 0 <= i {
+				tag = tag[:i]
+			}
+			if 0 < len(tag) {
+				return tag, true
+			}
+		}
+	}
+	return "", false
+}
+
+// findGoField looks for a struct field that matches a GraphQL name. A field
+// with a graphql tag (or json tag if withJSON is true) is matched only on the
+// tag value while untagged fields are matched on a case insensitive
+// comparison of the field name. A tag of "-" hides the field. Fields of
+// anonymous embedded structs are searched after the fields of the outer
+// struct.
+func findGoField(rt reflect.Type, name string, withJSON bool) (sf reflect.StructField, ok bool) {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct {
+		return
+	}
+	var embedded []reflect.StructField
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if tag, tagged := tagName(f, withJSON); tagged {
+			if tag == name {
+				return f, true
+			}
+			continue
+		}
+		if f.Anonymous {
+			embedded = append(embedded, f)
+			continue
+		}
+		if len(f.PkgPath) == 0 && strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	for _, e := range embedded {
+		if sf, ok = findGoField(e.Type, name, withJSON); ok {
+			sf.Index = append([]int{e.Index[0]}, sf.Index...)
+			return
+		}
+	}
+	return
+}
+
+// fieldByIndex returns the nested field by index like reflect.Value
+// FieldByIndex but allocates nil embedded struct pointers along the way
+// instead of panicking.
+func fieldByIndex(rv reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if 0 < i && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv
+}
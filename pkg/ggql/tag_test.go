@@ -0,0 +1,189 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ggql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+const tagSdl = `
+type Query {
+  title: String
+  subtitle: String
+  secret: String
+  songCount: Int
+  origin: String
+  total(range: Range): Int
+}
+
+input Range {
+  low: Int
+  high: Int = 10
+}
+`
+
+type TagSchema struct {
+	Query *TagQuery
+}
+
+type TagPlace struct {
+	Where string `graphql:"origin"`
+}
+
+type TagQuery struct {
+	TagPlace
+	Name     string `graphql:"title"`
+	Subtitle string `graphql:",omitempty"`
+	Secret   string `graphql:"-"`
+	Count    int    `json:"songCount,omitempty"`
+}
+
+type TagRange struct {
+	From int `graphql:"low"`
+	To   int `json:"high"`
+}
+
+func setupTagRoot(t *testing.T) *ggql.Root {
+	ggql.Sort = true
+	schema := TagSchema{
+		Query: &TagQuery{
+			TagPlace: TagPlace{Where: "Auckland"},
+			Name:     "Songs",
+			Subtitle: "by artist",
+			Secret:   "hidden",
+			Count:    8,
+		},
+	}
+	root := ggql.NewRoot(&schema)
+	err := root.ParseString(tagSdl)
+	checkNil(t, err, "no error should be returned when parsing a valid SDL. %s", err)
+
+	return root
+}
+
+func TestTagGraphQL(t *testing.T) {
+	root := setupTagRoot(t)
+
+	result := root.ResolveString("{title subtitle origin}", "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, 2)
+	checkEqual(t, `{
+  "data": {
+    "origin": "Auckland",
+    "subtitle": "by artist",
+    "title": "Songs"
+  }
+}
+`, b.String(), "result should match")
+}
+
+func TestTagHidden(t *testing.T) {
+	root := setupTagRoot(t)
+
+	result := root.ResolveString("{secret}", "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, 2)
+	checkEqual(t, `{
+  "data": {
+    "secret": null
+  },
+  "errors": [
+    {
+      "locations": [
+        {
+          "column": 3,
+          "line": 1
+        }
+      ],
+      "message": "resolve error: reflection error: secret is not a field of *ggql_test.TagQuery",
+      "path": [
+        "secret"
+      ]
+    }
+  ]
+}
+`, b.String(), "result should match")
+}
+
+func TestTagJSON(t *testing.T) {
+	root := setupTagRoot(t)
+
+	result := root.ResolveString("{songCount}", "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, 2)
+	checkEqual(t, true, strings.Contains(b.String(), "songCount is not a field"), "json tags should be ignored by default")
+
+	root = setupTagRoot(t)
+	root.JSONTags = true
+	result = root.ResolveString("{songCount}", "", nil)
+	b.Reset()
+	_ = ggql.WriteJSONValue(&b, result, 2)
+	checkEqual(t, `{
+  "data": {
+    "songCount": 8
+  }
+}
+`, b.String(), "result should match")
+}
+
+func TestTagInput(t *testing.T) {
+	root := setupTagRoot(t)
+	root.JSONTags = true
+	err := root.RegisterType(&TagRange{}, "Range")
+	checkNil(t, err, "no error should be returned when registering a type. %s", err)
+
+	input, _ := root.GetType("Range").(*ggql.Input)
+	checkNotNil(t, input, "Range should be an input type")
+
+	v, err := input.CoerceIn(map[string]interface{}{"low": int64(3)})
+	checkNil(t, err, "CoerceIn should not fail. %s", err)
+	r, _ := v.(*TagRange)
+	checkNotNil(t, r, "CoerceIn should return a *TagRange")
+	checkEqual(t, 3, r.From, "tagged field should be set")
+	checkEqual(t, 10, r.To, "json tagged field should be set to the default")
+}
+
+type tagFirst struct {
+	Name string
+}
+
+type tagSecond struct {
+	Name string
+}
+
+type tagAmbiguous struct {
+	Query *tagAmbiguousQuery
+}
+
+type tagAmbiguousQuery struct {
+	tagFirst
+	tagSecond
+}
+
+func TestTagEmbeddedAmbiguous(t *testing.T) {
+	root := ggql.NewRoot(&tagAmbiguous{
+		Query: &tagAmbiguousQuery{tagFirst: tagFirst{Name: "first"}, tagSecond: tagSecond{Name: "second"}},
+	})
+	err := root.ParseString("type Query { name: String }")
+	checkNil(t, err, "parse failed. %s", err)
+
+	result := root.ResolveString("{name}", "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, `{"data":{"name":"first"}}`, b.String(), "the first embedded field should be used")
+}
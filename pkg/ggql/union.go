@@ -114,6 +114,7 @@ func (t *Union) Validate(root *Root) (errs []error) {
 //   enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
 //   inputfields: [__InputValue!]
 //   ofType: __Type
+//   specifiedByURL: String
 func (t *Union) Resolve(field *Field, args map[string]interface{}) (result interface{}, err error) {
 	switch field.Name {
 	case kindStr:
@@ -126,7 +127,7 @@ func (t *Union) Resolve(field *Field, args map[string]interface{}) (result inter
 		list := newTypeList()
 		list.add(t.Members...)
 		result = list
-	case fieldsStr, interfacesStr, enumValuesStr, inputFieldsStr, ofTypeStr:
+	case fieldsStr, interfacesStr, enumValuesStr, inputFieldsStr, ofTypeStr, specifiedByURLStr:
 		// nil result
 	}
 	return
@@ -58,6 +58,49 @@ func FormErrorsResult(err error) []interface{} {
 	return eList
 }
 
+// FormErrors forms an errors array in the same way as FormErrorsResult but
+// with the "code" extension added if ErrorCodes is set and each error
+// passed through the ErrorPresenter if one is set. The ResolveBytes(),
+// ResolveString(), ResolveReader(), ResolveRequest(), and streaming
+// functions of the root form errors with FormErrors.
+func (root *Root) FormErrors(err error) []interface{} {
+	if !root.ErrorCodes && root.ErrorPresenter == nil {
+		return FormErrorsResult(err)
+	}
+	var ea Errors
+	if !errors.As(err, &ea) {
+		ea = Errors{err}
+	}
+	eList := make([]interface{}, 0, len(ea))
+	for _, e := range ea {
+		var ge *Error
+		if errors.As(e, &ge) {
+			// Copy so the original error is not modified.
+			cp := *ge
+			ge = &cp
+		} else {
+			ge = &Error{Base: e}
+		}
+		if root.ErrorCodes {
+			if _, has := ge.Extensions["code"]; !has {
+				ext := make(map[string]interface{}, len(ge.Extensions)+1)
+				for k, v := range ge.Extensions {
+					ext[k] = v
+				}
+				ext["code"] = ErrorCode(e)
+				ge.Extensions = ext
+			}
+		}
+		if root.ErrorPresenter != nil {
+			if ge = root.ErrorPresenter(ge); ge == nil {
+				continue
+			}
+		}
+		eList = append(eList, formOneErrorResult(ge))
+	}
+	return eList
+}
+
 func formOneErrorResult(err error) map[string]interface{} {
 	em := map[string]interface{}{}
 	var e *Error
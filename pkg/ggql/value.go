@@ -87,6 +87,8 @@ func writeValue(w io.Writer, v interface{}, sdl bool, depth, indent int) (err er
 			}
 		case map[string]interface{}:
 			err = writeMap(w, tv, sdl, depth, indent)
+		case *OrderedMap:
+			err = writeMembers(w, tv.Keys, tv.Values, sdl, depth, indent)
 		case []interface{}:
 			d2 := depth + 1
 			var i2 []byte
@@ -141,6 +143,21 @@ func writeValue(w io.Writer, v interface{}, sdl bool, depth, indent int) (err er
 }
 
 func writeMap(w io.Writer, m map[string]interface{}, sdl bool, depth, indent int) (err error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	if Sort {
+		sort.Strings(keys)
+	}
+	vals := make([]interface{}, len(keys))
+	for i, k := range keys {
+		vals[i] = m[k]
+	}
+	return writeMembers(w, keys, vals, sdl, depth, indent)
+}
+
+func writeMembers(w io.Writer, keys []string, vals []interface{}, sdl bool, depth, indent int) (err error) {
 	d2 := depth + 1
 	space := 0 <= indent
 	var i2 []byte
@@ -149,7 +166,8 @@ func writeMap(w io.Writer, m map[string]interface{}, sdl bool, depth, indent int
 	}
 	_, err = w.Write([]byte{'{'})
 	noSep := true
-	wv := func(key string, vv interface{}) {
+	for i, key := range keys {
+		vv := vals[i]
 		if err == nil && (!sdl || indent <= 0) && !noSep {
 			_, err = w.Write([]byte{','})
 			if err == nil && indent == 0 {
@@ -179,20 +197,6 @@ func writeMap(w io.Writer, m map[string]interface{}, sdl bool, depth, indent int
 		}
 		noSep = indent < 0 && sdl && isCollection(vv)
 	}
-	if Sort {
-		keys := make([]string, 0, len(m))
-		for k := range m {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
-		for _, k := range keys {
-			wv(k, m[k])
-		}
-	} else {
-		for k, v2 := range m {
-			wv(k, v2)
-		}
-	}
 	if err == nil {
 		if 0 < indent {
 			_, err = w.Write([]byte{'\n'})
@@ -212,7 +216,7 @@ func writeMap(w io.Writer, m map[string]interface{}, sdl bool, depth, indent int
 
 func isCollection(v interface{}) bool {
 	switch v.(type) {
-	case []interface{}, map[string]interface{}:
+	case []interface{}, map[string]interface{}, *OrderedMap:
 		return true
 	default:
 		return false
@@ -44,9 +44,7 @@ func (v *VarDef) Validate(root *Root) (errs []error) {
 		errs = append(errs, fmt.Errorf("%w: %s is not a valid input type for $%s at %d:%d",
 			ErrValidation, v.Type.Name(), v.Name, v.line, v.col))
 	}
-	for _, du := range v.Dirs {
-		errs = append(errs, root.validateDirUse(v.Name, Locate(v), du)...)
-	}
+	errs = append(errs, root.validateDirUseList(v.Name, Locate(v), v.Dirs)...)
 	return
 }
 
@@ -0,0 +1,71 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// Base64 is binary data encoded as a standard, padded base64 string. Values
+// are []byte on the Go side.
+type Base64 struct {
+	ggql.Scalar
+}
+
+// NewBase64 returns a new Base64 scalar.
+func NewBase64() ggql.Type {
+	return &Base64{
+		Scalar: newScalar("Base64",
+			"Base64 is binary data encoded as a base64 string.",
+			"https://www.rfc-editor.org/rfc/rfc4648#section-4"),
+	}
+}
+
+// CoerceIn coerces an input value into a []byte if possible otherwise an
+// error is returned.
+func (t *Base64) CoerceIn(v interface{}) (interface{}, error) {
+	switch tv := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		b, err := base64.StdEncoding.DecodeString(tv)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q into a %s", ggql.ErrCoerce, tv, t.N)
+		}
+		return b, nil
+	case []byte:
+		return tv, nil
+	}
+	return nil, newCoerceErr(v, t.N)
+}
+
+// CoerceOut coerces a result value into a base64 string.
+func (t *Base64) CoerceOut(v interface{}) (interface{}, error) {
+	switch tv := v.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return base64.StdEncoding.EncodeToString(tv), nil
+	case string:
+		if _, err := t.CoerceIn(tv); err != nil {
+			return nil, err
+		}
+		return tv, nil
+	}
+	return nil, newCoerceErr(v, t.N)
+}
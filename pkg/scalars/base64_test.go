@@ -0,0 +1,55 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars_test
+
+import (
+	"testing"
+
+	"github.com/uhn/ggql/pkg/scalars"
+)
+
+func TestBase64(t *testing.T) {
+	in, out := coercers(t, scalars.NewBase64())
+
+	v, err := in.CoerceIn("aGVsbG8=")
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkEqual(t, []byte("hello"), v, "CoerceIn string")
+
+	v, err = in.CoerceIn([]byte("hi"))
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkEqual(t, []byte("hi"), v, "CoerceIn bytes")
+
+	v, err = in.CoerceIn(nil)
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkNil(t, v, "CoerceIn nil")
+
+	_, err = in.CoerceIn("not base64!")
+	checkNotNil(t, err, "CoerceIn of an invalid string should fail")
+	_, err = in.CoerceIn(1)
+	checkNotNil(t, err, "CoerceIn of an int should fail")
+
+	v, err = out.CoerceOut([]byte("hello"))
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "aGVsbG8=", v, "CoerceOut bytes")
+
+	v, err = out.CoerceOut("aGVsbG8=")
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "aGVsbG8=", v, "CoerceOut string")
+
+	_, err = out.CoerceOut("===")
+	checkNotNil(t, err, "CoerceOut of an invalid string should fail")
+	_, err = out.CoerceOut(1)
+	checkNotNil(t, err, "CoerceOut of an int should fail")
+}
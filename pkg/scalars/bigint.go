@@ -0,0 +1,81 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// BigInt is an arbitrary precision integer. Input values are coerced to a
+// *big.Int and result values are written as decimal strings so that no
+// precision is lost by JSON parsers that use float64 for numbers.
+type BigInt struct {
+	ggql.Scalar
+}
+
+// NewBigInt returns a new BigInt scalar.
+func NewBigInt() ggql.Type {
+	return &BigInt{
+		Scalar: newScalar("BigInt",
+			"BigInt is an arbitrary precision integer represented as a decimal string.",
+			"https://pkg.go.dev/math/big#Int.SetString"),
+	}
+}
+
+// CoerceIn coerces an input value into a *big.Int if possible otherwise an
+// error is returned.
+func (t *BigInt) CoerceIn(v interface{}) (interface{}, error) {
+	switch tv := v.(type) {
+	case nil:
+		return nil, nil
+	case int64:
+		return big.NewInt(tv), nil
+	case int32:
+		return big.NewInt(int64(tv)), nil
+	case int:
+		return big.NewInt(int64(tv)), nil
+	case string:
+		if bi, ok := new(big.Int).SetString(tv, 10); ok {
+			return bi, nil
+		}
+		return nil, fmt.Errorf("%w %q into a %s", ggql.ErrCoerce, tv, t.N)
+	case *big.Int:
+		return tv, nil
+	}
+	return nil, newCoerceErr(v, t.N)
+}
+
+// CoerceOut coerces a result value into a decimal string.
+func (t *BigInt) CoerceOut(v interface{}) (interface{}, error) {
+	switch tv := v.(type) {
+	case nil:
+		return nil, nil
+	case *big.Int:
+		if tv == nil {
+			return nil, nil
+		}
+		return tv.String(), nil
+	case big.Int:
+		return tv.String(), nil
+	}
+	bi, err := t.CoerceIn(v)
+	if err != nil {
+		return nil, err
+	}
+	return bi.(*big.Int).String(), nil
+}
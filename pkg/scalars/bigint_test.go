@@ -0,0 +1,59 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/scalars"
+)
+
+func TestBigInt(t *testing.T) {
+	in, out := coercers(t, scalars.NewBigInt())
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	v, err := in.CoerceIn("123456789012345678901234567890")
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkEqual(t, huge, v, "CoerceIn string")
+
+	for _, x := range []interface{}{int64(12), int32(12), 12} {
+		v, err = in.CoerceIn(x)
+		checkNil(t, err, "CoerceIn error. %s", err)
+		checkEqual(t, big.NewInt(12), v, "CoerceIn %T", x)
+	}
+	v, err = in.CoerceIn(nil)
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkNil(t, v, "CoerceIn nil")
+
+	for _, bad := range []interface{}{"12.5", 12.5} {
+		_, err = in.CoerceIn(bad)
+		checkNotNil(t, err, "CoerceIn of %v should fail", bad)
+	}
+	v, err = out.CoerceOut(huge)
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "123456789012345678901234567890", v, "CoerceOut *big.Int")
+
+	v, err = out.CoerceOut(*huge)
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "123456789012345678901234567890", v, "CoerceOut big.Int")
+
+	v, err = out.CoerceOut(int64(-3))
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "-3", v, "CoerceOut int64")
+
+	_, err = out.CoerceOut(true)
+	checkNotNil(t, err, "CoerceOut of a bool should fail")
+}
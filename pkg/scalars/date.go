@@ -0,0 +1,87 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars
+
+import (
+	"time"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// DateLayout is the layout of a Date as a string.
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date without a time of day. It coerces to and from a
+// string in the RFC 3339 full-date format and is represented by a time.Time
+// in UTC on the Go side.
+type Date struct {
+	ggql.Scalar
+}
+
+// NewDate returns a new Date scalar.
+func NewDate() ggql.Type {
+	return &Date{
+		Scalar: newScalar("Date",
+			"Date is a calendar date formatted as YYYY-MM-DD.",
+			"https://www.rfc-editor.org/rfc/rfc3339#section-5.6"),
+	}
+}
+
+// CoerceIn coerces an input value into the expected input type if possible
+// otherwise an error is returned.
+func (t *Date) CoerceIn(v interface{}) (interface{}, error) {
+	var err error
+	switch tv := v.(type) {
+	case nil:
+		// remains nil
+	case string:
+		var d time.Time
+		if d, err = time.Parse(DateLayout, tv); err == nil {
+			v = d
+		}
+	case time.Time:
+		v = truncDate(tv)
+	default:
+		err = newCoerceErr(v, t.N)
+	}
+	if err != nil {
+		v = nil
+	}
+	return v, err
+}
+
+// CoerceOut coerces a result value into a string in the Date format.
+func (t *Date) CoerceOut(v interface{}) (interface{}, error) {
+	var err error
+	switch tv := v.(type) {
+	case nil:
+		// remains nil
+	case string:
+		if _, err = time.Parse(DateLayout, tv); err != nil {
+			v = nil
+		}
+	case time.Time:
+		v = tv.Format(DateLayout)
+	default:
+		err = newCoerceErr(v, t.N)
+		v = nil
+	}
+	return v, err
+}
+
+func truncDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
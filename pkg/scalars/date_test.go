@@ -0,0 +1,57 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/uhn/ggql/pkg/scalars"
+)
+
+func TestDate(t *testing.T) {
+	in, out := coercers(t, scalars.NewDate())
+	d := time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	v, err := in.CoerceIn("2020-02-29")
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkEqual(t, d, v, "CoerceIn string")
+
+	v, err = in.CoerceIn(d.Add(5 * time.Hour))
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkEqual(t, d, v, "CoerceIn time")
+
+	v, err = in.CoerceIn(nil)
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkNil(t, v, "CoerceIn nil")
+
+	_, err = in.CoerceIn("2020-02-30")
+	checkNotNil(t, err, "CoerceIn of an invalid date should fail")
+	_, err = in.CoerceIn(true)
+	checkNotNil(t, err, "CoerceIn of a bool should fail")
+
+	v, err = out.CoerceOut(d)
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "2020-02-29", v, "CoerceOut time")
+
+	v, err = out.CoerceOut("2020-02-29")
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "2020-02-29", v, "CoerceOut string")
+
+	_, err = out.CoerceOut("Feb 29")
+	checkNotNil(t, err, "CoerceOut of an invalid date should fail")
+	_, err = out.CoerceOut(3)
+	checkNotNil(t, err, "CoerceOut of an int should fail")
+}
@@ -0,0 +1,90 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// decimalPrec is the precision in bits of Decimal input values.
+const decimalPrec = 256
+
+// Decimal is an arbitrary precision decimal number. Input values are
+// coerced to a *big.Float and result values are written as decimal strings
+// so no precision is lost. A string can also be returned by a resolver as
+// long as it is a valid decimal number.
+type Decimal struct {
+	ggql.Scalar
+}
+
+// NewDecimal returns a new Decimal scalar.
+func NewDecimal() ggql.Type {
+	return &Decimal{
+		Scalar: newScalar("Decimal",
+			"Decimal is an arbitrary precision decimal number represented as a string.",
+			"https://pkg.go.dev/math/big#Float.SetString"),
+	}
+}
+
+// CoerceIn coerces an input value into a *big.Float if possible otherwise
+// an error is returned.
+func (t *Decimal) CoerceIn(v interface{}) (interface{}, error) {
+	switch tv := v.(type) {
+	case nil:
+		return nil, nil
+	case int64:
+		return new(big.Float).SetPrec(decimalPrec).SetInt64(tv), nil
+	case int32:
+		return new(big.Float).SetPrec(decimalPrec).SetInt64(int64(tv)), nil
+	case int:
+		return new(big.Float).SetPrec(decimalPrec).SetInt64(int64(tv)), nil
+	case float64:
+		return new(big.Float).SetPrec(decimalPrec).SetFloat64(tv), nil
+	case string:
+		if bf, ok := new(big.Float).SetPrec(decimalPrec).SetString(tv); ok {
+			return bf, nil
+		}
+		return nil, fmt.Errorf("%w %q into a %s", ggql.ErrCoerce, tv, t.N)
+	case *big.Float:
+		return tv, nil
+	}
+	return nil, newCoerceErr(v, t.N)
+}
+
+// CoerceOut coerces a result value into a decimal string.
+func (t *Decimal) CoerceOut(v interface{}) (interface{}, error) {
+	switch tv := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if _, err := t.CoerceIn(tv); err != nil {
+			return nil, err
+		}
+		return tv, nil
+	case *big.Float:
+		if tv == nil {
+			return nil, nil
+		}
+		return tv.Text('f', -1), nil
+	}
+	bf, err := t.CoerceIn(v)
+	if err != nil {
+		return nil, err
+	}
+	return bf.(*big.Float).Text('f', -1), nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/scalars"
+)
+
+func TestDecimal(t *testing.T) {
+	in, out := coercers(t, scalars.NewDecimal())
+
+	v, err := in.CoerceIn("12345678901234567890.125")
+	checkNil(t, err, "CoerceIn error. %s", err)
+	bf, _ := v.(*big.Float)
+	checkNotNil(t, bf, "CoerceIn should return a *big.Float")
+	checkEqual(t, "12345678901234567890.125", bf.Text('f', -1), "CoerceIn string")
+
+	for _, x := range []interface{}{int64(2), int32(2), 2, 2.0} {
+		v, err = in.CoerceIn(x)
+		checkNil(t, err, "CoerceIn error. %s", err)
+		checkEqual(t, "2", v.(*big.Float).Text('f', -1), "CoerceIn %T", x)
+	}
+	v, err = in.CoerceIn(nil)
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkNil(t, v, "CoerceIn nil")
+
+	_, err = in.CoerceIn("1.2.3")
+	checkNotNil(t, err, "CoerceIn of an invalid number should fail")
+	_, err = in.CoerceIn(false)
+	checkNotNil(t, err, "CoerceIn of a bool should fail")
+
+	v, err = out.CoerceOut(bf)
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "12345678901234567890.125", v, "CoerceOut *big.Float")
+
+	v, err = out.CoerceOut("0.1")
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "0.1", v, "CoerceOut string")
+
+	v, err = out.CoerceOut(1.5)
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "1.5", v, "CoerceOut float64")
+
+	_, err = out.CoerceOut("abc")
+	checkNotNil(t, err, "CoerceOut of an invalid number should fail")
+}
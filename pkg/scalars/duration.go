@@ -0,0 +1,181 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// Duration is an ISO 8601 duration such as P1DT2H30M. It is represented by
+// a time.Duration on the Go side so only the week, day, hour, minute, and
+// second designators are supported. Years and months are rejected as they
+// do not have a fixed length. A day is taken to be 24 hours.
+type Duration struct {
+	ggql.Scalar
+}
+
+// NewDuration returns a new Duration scalar.
+func NewDuration() ggql.Type {
+	return &Duration{
+		Scalar: newScalar("Duration",
+			"Duration is an ISO 8601 duration such as PT1H30M.",
+			"https://en.wikipedia.org/wiki/ISO_8601#Durations"),
+	}
+}
+
+// CoerceIn coerces an input value into a time.Duration if possible otherwise
+// an error is returned. Integers are taken to be nanoseconds.
+func (t *Duration) CoerceIn(v interface{}) (interface{}, error) {
+	var err error
+	switch tv := v.(type) {
+	case nil:
+		// remains nil
+	case string:
+		v, err = ParseDuration(tv)
+	case int64:
+		v = time.Duration(tv)
+	case time.Duration:
+		// ok as is
+	default:
+		err = newCoerceErr(v, t.N)
+	}
+	if err != nil {
+		v = nil
+	}
+	return v, err
+}
+
+// CoerceOut coerces a result value into an ISO 8601 duration string.
+func (t *Duration) CoerceOut(v interface{}) (interface{}, error) {
+	var err error
+	switch tv := v.(type) {
+	case nil:
+		// remains nil
+	case string:
+		if _, err = ParseDuration(tv); err != nil {
+			v = nil
+		}
+	case time.Duration:
+		v = FormatDuration(tv)
+	case int64:
+		v = FormatDuration(time.Duration(tv))
+	default:
+		err = newCoerceErr(v, t.N)
+		v = nil
+	}
+	return v, err
+}
+
+// ParseDuration parses an ISO 8601 duration string such as P3DT4H5M6.5S.
+func ParseDuration(s string) (d time.Duration, err error) {
+	str := s
+	neg := false
+	if strings.HasPrefix(str, "-") {
+		neg = true
+		str = str[1:]
+	}
+	if !strings.HasPrefix(str, "P") {
+		return 0, fmt.Errorf("%w %q into a Duration", ggql.ErrCoerce, s)
+	}
+	str = str[1:]
+	inTime := false
+	found := false
+	for 0 < len(str) {
+		if str[0] == 'T' {
+			if inTime || len(str) == 1 {
+				return 0, fmt.Errorf("%w %q into a Duration", ggql.ErrCoerce, s)
+			}
+			inTime = true
+			str = str[1:]
+			continue
+		}
+		i := strings.IndexAny(str, "WDHMSY")
+		if i <= 0 {
+			return 0, fmt.Errorf("%w %q into a Duration", ggql.ErrCoerce, s)
+		}
+		var n float64
+		if n, err = strconv.ParseFloat(str[:i], 64); err != nil || n < 0 {
+			return 0, fmt.Errorf("%w %q into a Duration", ggql.ErrCoerce, s)
+		}
+		var unit time.Duration
+		switch {
+		case str[i] == 'W' && !inTime:
+			unit = 7 * 24 * time.Hour
+		case str[i] == 'D' && !inTime:
+			unit = 24 * time.Hour
+		case str[i] == 'H' && inTime:
+			unit = time.Hour
+		case str[i] == 'M' && inTime:
+			unit = time.Minute
+		case str[i] == 'S' && inTime:
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("%w %q into a Duration, only W, D, H, M, and S are supported", ggql.ErrCoerce, s)
+		}
+		d += time.Duration(n * float64(unit))
+		str = str[i+1:]
+		found = true
+	}
+	if !found {
+		return 0, fmt.Errorf("%w %q into a Duration", ggql.ErrCoerce, s)
+	}
+	if neg {
+		d = -d
+	}
+	return
+}
+
+// FormatDuration returns d as an ISO 8601 duration string. Days are used
+// for multiples of 24 hours and fractional seconds are included when
+// needed.
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	b.WriteByte('P')
+	if days := d / (24 * time.Hour); 0 < days {
+		b.WriteString(strconv.FormatInt(int64(days), 10))
+		b.WriteByte('D')
+		d -= days * 24 * time.Hour
+	}
+	if 0 < d {
+		b.WriteByte('T')
+		if h := d / time.Hour; 0 < h {
+			b.WriteString(strconv.FormatInt(int64(h), 10))
+			b.WriteByte('H')
+			d -= h * time.Hour
+		}
+		if m := d / time.Minute; 0 < m {
+			b.WriteString(strconv.FormatInt(int64(m), 10))
+			b.WriteByte('M')
+			d -= m * time.Minute
+		}
+		if 0 < d {
+			b.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64))
+			b.WriteByte('S')
+		}
+	}
+	return b.String()
+}
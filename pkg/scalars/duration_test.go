@@ -0,0 +1,95 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/uhn/ggql/pkg/scalars"
+)
+
+func TestDurationParse(t *testing.T) {
+	for s, d := range map[string]time.Duration{
+		"PT0S":           0,
+		"P1D":            24 * time.Hour,
+		"P1W":            7 * 24 * time.Hour,
+		"PT1H30M":        90 * time.Minute,
+		"P1DT2H3M4.5S":   26*time.Hour + 3*time.Minute + 4500*time.Millisecond,
+		"-PT10S":         -10 * time.Second,
+		"PT0.000001S":    time.Microsecond,
+		"P2DT0.25S":      48*time.Hour + 250*time.Millisecond,
+		"PT36H":          36 * time.Hour,
+		"PT1.5M":         90 * time.Second,
+		"P0DT0H0M1S":     time.Second,
+		"PT100000000S":   100000000 * time.Second,
+		"P10DT10H":       250 * time.Hour,
+		"PT2M0.0000001S": 2*time.Minute + 100*time.Nanosecond,
+	} {
+		v, err := scalars.ParseDuration(s)
+		checkNil(t, err, "ParseDuration(%q) error. %s", s, err)
+		checkEqual(t, d, v, "ParseDuration(%q)", s)
+	}
+	for _, s := range []string{"", "P", "PT", "1H", "P1Y", "P1M", "PT1D", "P1H", "PTH", "P-1D", "P1DT"} {
+		_, err := scalars.ParseDuration(s)
+		checkNotNil(t, err, "ParseDuration(%q) should fail", s)
+	}
+}
+
+func TestDurationFormat(t *testing.T) {
+	for d, s := range map[time.Duration]string{
+		0:                                    "PT0S",
+		24 * time.Hour:                       "P1D",
+		90 * time.Minute:                     "PT1H30M",
+		26*time.Hour + 4500*time.Millisecond: "P1DT2H4.5S",
+		-10 * time.Second:                    "-PT10S",
+	} {
+		checkEqual(t, s, scalars.FormatDuration(d), "FormatDuration(%s)", d)
+	}
+}
+
+func TestDuration(t *testing.T) {
+	in, out := coercers(t, scalars.NewDuration())
+
+	v, err := in.CoerceIn("PT1M")
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkEqual(t, time.Minute, v, "CoerceIn string")
+
+	v, err = in.CoerceIn(int64(1000))
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkEqual(t, time.Microsecond, v, "CoerceIn int64")
+
+	v, err = in.CoerceIn(nil)
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkNil(t, v, "CoerceIn nil")
+
+	_, err = in.CoerceIn("1m")
+	checkNotNil(t, err, "CoerceIn of a Go duration string should fail")
+	_, err = in.CoerceIn(1.5)
+	checkNotNil(t, err, "CoerceIn of a float should fail")
+
+	v, err = out.CoerceOut(time.Hour)
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "PT1H", v, "CoerceOut duration")
+
+	v, err = out.CoerceOut("P1D")
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "P1D", v, "CoerceOut string")
+
+	_, err = out.CoerceOut("bad")
+	checkNotNil(t, err, "CoerceOut of an invalid duration should fail")
+	_, err = out.CoerceOut(true)
+	checkNotNil(t, err, "CoerceOut of a bool should fail")
+}
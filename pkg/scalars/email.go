@@ -0,0 +1,61 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars
+
+import (
+	"fmt"
+	"net/mail"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// Email is an email address without a display name such as
+// someone@example.com. Values are strings on the Go side.
+type Email struct {
+	ggql.Scalar
+}
+
+// NewEmail returns a new Email scalar.
+func NewEmail() ggql.Type {
+	return &Email{
+		Scalar: newScalar("Email",
+			"Email is an email address such as someone@example.com.",
+			"https://www.rfc-editor.org/rfc/rfc5322#section-3.4.1"),
+	}
+}
+
+// CoerceIn coerces an input value into an email address string if possible
+// otherwise an error is returned.
+func (t *Email) CoerceIn(v interface{}) (interface{}, error) {
+	return t.coerce(v)
+}
+
+// CoerceOut coerces a result value into an email address string.
+func (t *Email) CoerceOut(v interface{}) (interface{}, error) {
+	return t.coerce(v)
+}
+
+func (t *Email) coerce(v interface{}) (interface{}, error) {
+	switch tv := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if addr, err := mail.ParseAddress(tv); err == nil && addr.Address == tv {
+			return tv, nil
+		}
+		return nil, fmt.Errorf("%w %q into a %s", ggql.ErrCoerce, tv, t.N)
+	}
+	return nil, newCoerceErr(v, t.N)
+}
@@ -0,0 +1,42 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars_test
+
+import (
+	"testing"
+
+	"github.com/uhn/ggql/pkg/scalars"
+)
+
+func TestEmail(t *testing.T) {
+	in, out := coercers(t, scalars.NewEmail())
+
+	v, err := in.CoerceIn("someone@example.com")
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkEqual(t, "someone@example.com", v, "CoerceIn string")
+
+	v, err = out.CoerceOut("someone@example.com")
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "someone@example.com", v, "CoerceOut string")
+
+	v, err = in.CoerceIn(nil)
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkNil(t, v, "CoerceIn nil")
+
+	for _, bad := range []interface{}{"someone", "Someone <someone@example.com>", "@example.com", 1} {
+		_, err = in.CoerceIn(bad)
+		checkNotNil(t, err, "CoerceIn of %v should fail", bad)
+	}
+}
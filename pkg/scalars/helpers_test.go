@@ -0,0 +1,55 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+func checkNil(t *testing.T, val interface{}, format string, args ...interface{}) {
+	t.Helper()
+	if !ggql.IsNil(val) {
+		t.Fatalf("\nexpect: nil, actual: %v\n"+format, append([]interface{}{val}, args...)...)
+	}
+}
+
+func checkNotNil(t *testing.T, val interface{}, format string, args ...interface{}) {
+	t.Helper()
+	if ggql.IsNil(val) {
+		t.Fatalf("\nexpect: not nil, actual: nil\n"+format, args...)
+	}
+}
+
+func checkEqual(t *testing.T, expect, actual interface{}, format string, args ...interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(expect, actual) {
+		t.Fatalf("\nexpect: %v (%T)\nactual: %v (%T)\n"+format,
+			append([]interface{}{expect, expect, actual, actual}, args...)...)
+	}
+}
+
+// coercers returns the scalar as both an InCoercer and an OutCoercer.
+func coercers(t *testing.T, st ggql.Type) (ggql.InCoercer, ggql.OutCoercer) {
+	t.Helper()
+	in, _ := st.(ggql.InCoercer)
+	checkNotNil(t, in, "%s should be an InCoercer", st.Name())
+	out, _ := st.(ggql.OutCoercer)
+	checkNotNil(t, out, "%s should be an OutCoercer", st.Name())
+
+	return in, out
+}
@@ -0,0 +1,62 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars
+
+import (
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// JSON is an arbitrary JSON value. Input values are passed through as the
+// generic types the parser produces, map[string]interface{},
+// []interface{}, string, int64, float64, bool, or nil. Enum like symbols in
+// an input object literal are converted to strings.
+type JSON struct {
+	ggql.Scalar
+}
+
+// NewJSON returns a new JSON scalar.
+func NewJSON() ggql.Type {
+	return &JSON{
+		Scalar: newScalar("JSON",
+			"JSON is an arbitrary JSON value.",
+			"https://www.rfc-editor.org/rfc/rfc8259"),
+	}
+}
+
+// CoerceIn returns the input value with any symbols converted to strings.
+func (t *JSON) CoerceIn(v interface{}) (interface{}, error) {
+	return jsonValue(v), nil
+}
+
+// CoerceOut returns the result value as is.
+func (t *JSON) CoerceOut(v interface{}) (interface{}, error) {
+	return v, nil
+}
+
+func jsonValue(v interface{}) interface{} {
+	switch tv := v.(type) {
+	case ggql.Symbol:
+		v = string(tv)
+	case map[string]interface{}:
+		for k, m := range tv {
+			tv[k] = jsonValue(m)
+		}
+	case []interface{}:
+		for i, m := range tv {
+			tv[i] = jsonValue(m)
+		}
+	}
+	return v
+}
@@ -0,0 +1,56 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+	"github.com/uhn/ggql/pkg/scalars"
+)
+
+type JSONQuery struct {
+}
+
+func (q *JSONQuery) Echo(v interface{}) interface{} {
+	return v
+}
+
+func TestJSON(t *testing.T) {
+	in, out := coercers(t, scalars.NewJSON())
+
+	v, err := in.CoerceIn(map[string]interface{}{"a": []interface{}{int64(1), ggql.Symbol("B")}})
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkEqual(t, map[string]interface{}{"a": []interface{}{int64(1), "B"}}, v, "CoerceIn map")
+
+	v, err = out.CoerceOut([]interface{}{true, nil})
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, []interface{}{true, nil}, v, "CoerceOut list")
+}
+
+func TestJSONResolve(t *testing.T) {
+	root := ggql.NewRoot(&struct{ Query *JSONQuery }{Query: &JSONQuery{}})
+	err := scalars.Register(root)
+	checkNil(t, err, "Register should not fail. %s", err)
+	err = root.ParseString(`type Query { echo(v: JSON): JSON }`)
+	checkNil(t, err, "ParseString should not fail. %s", err)
+
+	ggql.Sort = true
+	result := root.ResolveString(`{echo(v: {a: [1, 2.5, "x", true, null], b: {c: RED}})}`, "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, `{"data":{"echo":{"a":[1,2.5,"x",true,null],"b":{"c":"RED"}}}}`, b.String(), "result")
+}
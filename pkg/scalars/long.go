@@ -0,0 +1,77 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// Long is a 64 bit signed integer. Input values are coerced to an int64 and
+// can be a number or a string of decimal digits.
+type Long struct {
+	ggql.Scalar
+}
+
+// NewLong returns a new Long scalar.
+func NewLong() ggql.Type {
+	return &Long{
+		Scalar: newScalar("Long",
+			"Long is a 64 bit signed integer.",
+			"https://pkg.go.dev/builtin#int64"),
+	}
+}
+
+// CoerceIn coerces an input value into an int64 if possible otherwise an
+// error is returned.
+func (t *Long) CoerceIn(v interface{}) (interface{}, error) {
+	switch tv := v.(type) {
+	case nil:
+		return nil, nil
+	case int64:
+		return tv, nil
+	case int32:
+		return int64(tv), nil
+	case int:
+		return int64(tv), nil
+	case float64:
+		if tv == math.Trunc(tv) && math.MinInt64 <= tv && tv < math.MaxInt64 {
+			return int64(tv), nil
+		}
+	case string:
+		if i, err := strconv.ParseInt(tv, 10, 64); err == nil {
+			return i, nil
+		}
+		return nil, fmt.Errorf("%w %q into a %s", ggql.ErrCoerce, tv, t.N)
+	}
+	return nil, newCoerceErr(v, t.N)
+}
+
+// CoerceOut coerces a result value into an int64.
+func (t *Long) CoerceOut(v interface{}) (interface{}, error) {
+	switch tv := v.(type) {
+	case uint32:
+		return int64(tv), nil
+	case uint64:
+		if tv <= math.MaxInt64 {
+			return int64(tv), nil
+		}
+		return nil, newCoerceErr(v, t.N)
+	}
+	return t.CoerceIn(v)
+}
@@ -0,0 +1,50 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/scalars"
+)
+
+func TestLong(t *testing.T) {
+	in, out := coercers(t, scalars.NewLong())
+
+	for _, x := range []interface{}{int64(42), int32(42), 42, 42.0, "42"} {
+		v, err := in.CoerceIn(x)
+		checkNil(t, err, "CoerceIn error. %s", err)
+		checkEqual(t, int64(42), v, "CoerceIn %T", x)
+	}
+	v, err := in.CoerceIn(nil)
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkNil(t, v, "CoerceIn nil")
+
+	for _, bad := range []interface{}{42.5, "42.5", "99999999999999999999", 1e20, true} {
+		_, err = in.CoerceIn(bad)
+		checkNotNil(t, err, "CoerceIn of %v should fail", bad)
+	}
+	v, err = out.CoerceOut(uint64(math.MaxInt64))
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, int64(math.MaxInt64), v, "CoerceOut uint64")
+
+	v, err = out.CoerceOut(uint32(7))
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, int64(7), v, "CoerceOut uint32")
+
+	_, err = out.CoerceOut(uint64(math.MaxUint64))
+	checkNotNil(t, err, "CoerceOut of a too large uint64 should fail")
+}
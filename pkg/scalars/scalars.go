@@ -0,0 +1,67 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scalars provides GraphQL scalar types beyond the Time and Int64
+// scalars included in the ggql package. Each scalar includes a
+// @specifiedBy directive with the URL of the specification the scalar
+// follows. All the scalars can be added to a ggql.Root with a single call to
+// Register().
+package scalars
+
+import (
+	"fmt"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// All returns a new instance of each of the scalars in the package.
+func All() []ggql.Type {
+	return []ggql.Type{
+		NewDate(),
+		NewDuration(),
+		NewUUID(),
+		NewURL(),
+		NewEmail(),
+		NewJSON(),
+		NewBigInt(),
+		NewDecimal(),
+		NewBase64(),
+		NewLong(),
+	}
+}
+
+// Register adds all the scalars in the package to root. Scalars with names
+// that are already in the schema are not replaced.
+func Register(root *ggql.Root) error {
+	return root.AddTypes(All()...)
+}
+
+func newScalar(name, desc, url string) ggql.Scalar {
+	return ggql.Scalar{
+		Base: ggql.Base{
+			N:    name,
+			Desc: desc,
+			Dirs: []*ggql.DirectiveUse{
+				{
+					Directive: &ggql.Ref{Base: ggql.Base{N: "specifiedBy"}},
+					Args:      map[string]*ggql.ArgValue{"url": {Arg: "url", Value: url}},
+				},
+			},
+		},
+	}
+}
+
+func newCoerceErr(v interface{}, name string) error {
+	return fmt.Errorf("%w a %T into a %s", ggql.ErrCoerce, v, name)
+}
@@ -0,0 +1,56 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/ggql"
+	"github.com/uhn/ggql/pkg/scalars"
+)
+
+type Query struct {
+	When string
+}
+
+type Schema struct {
+	Query *Query
+}
+
+func TestRegister(t *testing.T) {
+	root := ggql.NewRoot(&Schema{Query: &Query{When: "2020-02-29"}})
+	err := scalars.Register(root)
+	checkNil(t, err, "Register should not fail. %s", err)
+
+	err = root.ParseString(`type Query { when: Date }`)
+	checkNil(t, err, "ParseString should not fail. %s", err)
+
+	for _, st := range scalars.All() {
+		checkNotNil(t, root.GetType(st.Name()), "%s should be registered", st.Name())
+	}
+	sdl := root.GetType("Date").SDL()
+	checkEqual(t, `scalar Date @specifiedBy(url: "https://www.rfc-editor.org/rfc/rfc3339#section-5.6")`,
+		strings.TrimSpace(sdl), "Date SDL")
+
+	result := root.ResolveString("{when}", "", nil)
+	var b strings.Builder
+	_ = ggql.WriteJSONValue(&b, result, -1)
+	checkEqual(t, `{"data":{"when":"2020-02-29"}}`, b.String(), "result")
+
+	// A second registration leaves the existing scalars in place.
+	err = scalars.Register(root)
+	checkNil(t, err, "Register again should not fail. %s", err)
+}
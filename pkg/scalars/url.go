@@ -0,0 +1,80 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// URL is an absolute URL. Input values are coerced to a *url.URL and result
+// values can be a *url.URL, a url.URL, or a string.
+type URL struct {
+	ggql.Scalar
+}
+
+// NewURL returns a new URL scalar.
+func NewURL() ggql.Type {
+	return &URL{
+		Scalar: newScalar("URL",
+			"URL is an absolute URL such as https://example.com/path.",
+			"https://url.spec.whatwg.org/"),
+	}
+}
+
+// CoerceIn coerces an input value into a *url.URL if possible otherwise an
+// error is returned.
+func (t *URL) CoerceIn(v interface{}) (interface{}, error) {
+	switch tv := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return t.parse(tv)
+	case *url.URL:
+		return tv, nil
+	}
+	return nil, newCoerceErr(v, t.N)
+}
+
+// CoerceOut coerces a result value into a string.
+func (t *URL) CoerceOut(v interface{}) (interface{}, error) {
+	switch tv := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if _, err := t.parse(tv); err != nil {
+			return nil, err
+		}
+		return tv, nil
+	case *url.URL:
+		if tv == nil {
+			return nil, nil
+		}
+		return tv.String(), nil
+	case url.URL:
+		return tv.String(), nil
+	}
+	return nil, newCoerceErr(v, t.N)
+}
+
+func (t *URL) parse(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil || !u.IsAbs() {
+		return nil, fmt.Errorf("%w %q into a %s", ggql.ErrCoerce, s, t.N)
+	}
+	return u, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/uhn/ggql/pkg/scalars"
+)
+
+func TestURL(t *testing.T) {
+	in, out := coercers(t, scalars.NewURL())
+
+	v, err := in.CoerceIn("https://example.com/path?q=1")
+	checkNil(t, err, "CoerceIn error. %s", err)
+	u, _ := v.(*url.URL)
+	checkNotNil(t, u, "CoerceIn should return a *url.URL")
+	checkEqual(t, "example.com", u.Host, "CoerceIn host")
+
+	v, err = in.CoerceIn(u)
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkEqual(t, u, v, "CoerceIn *url.URL")
+
+	v, err = in.CoerceIn(nil)
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkNil(t, v, "CoerceIn nil")
+
+	for _, bad := range []interface{}{"/relative/path", "http://bad host", true} {
+		_, err = in.CoerceIn(bad)
+		checkNotNil(t, err, "CoerceIn of %v should fail", bad)
+	}
+	v, err = out.CoerceOut(u)
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "https://example.com/path?q=1", v, "CoerceOut *url.URL")
+
+	v, err = out.CoerceOut(*u)
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "https://example.com/path?q=1", v, "CoerceOut url.URL")
+
+	v, err = out.CoerceOut("https://example.com")
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "https://example.com", v, "CoerceOut string")
+
+	_, err = out.CoerceOut("example")
+	checkNotNil(t, err, "CoerceOut of a relative URL should fail")
+	_, err = out.CoerceOut(1)
+	checkNotNil(t, err, "CoerceOut of an int should fail")
+}
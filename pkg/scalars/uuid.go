@@ -0,0 +1,84 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/uhn/ggql/pkg/ggql"
+)
+
+// UUID is a universally unique identifier in the canonical 8-4-4-4-12
+// hexadecimal form. Values are represented as lowercase strings on the Go
+// side although a [16]byte is also accepted as a result value.
+type UUID struct {
+	ggql.Scalar
+}
+
+// NewUUID returns a new UUID scalar.
+func NewUUID() ggql.Type {
+	return &UUID{
+		Scalar: newScalar("UUID",
+			"UUID is a universally unique identifier such as 123e4567-e89b-12d3-a456-426614174000.",
+			"https://www.rfc-editor.org/rfc/rfc4122"),
+	}
+}
+
+// CoerceIn coerces an input value into a lowercase UUID string if possible
+// otherwise an error is returned.
+func (t *UUID) CoerceIn(v interface{}) (interface{}, error) {
+	return t.coerce(v)
+}
+
+// CoerceOut coerces a result value into a lowercase UUID string.
+func (t *UUID) CoerceOut(v interface{}) (interface{}, error) {
+	if b, ok := v.([16]byte); ok {
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+	}
+	return t.coerce(v)
+}
+
+func (t *UUID) coerce(v interface{}) (interface{}, error) {
+	switch tv := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if validUUID(tv) {
+			return strings.ToLower(tv), nil
+		}
+		return nil, fmt.Errorf("%w %q into a %s", ggql.ErrCoerce, tv, t.N)
+	}
+	return nil, newCoerceErr(v, t.N)
+}
+
+func validUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, c := range []byte(s) {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
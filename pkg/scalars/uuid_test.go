@@ -0,0 +1,44 @@
+// Copyright 2019-2020 University Health Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalars_test
+
+import (
+	"testing"
+
+	"github.com/uhn/ggql/pkg/scalars"
+)
+
+func TestUUID(t *testing.T) {
+	in, out := coercers(t, scalars.NewUUID())
+
+	v, err := in.CoerceIn("123E4567-E89B-12D3-A456-426614174000")
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkEqual(t, "123e4567-e89b-12d3-a456-426614174000", v, "CoerceIn string")
+
+	v, err = in.CoerceIn(nil)
+	checkNil(t, err, "CoerceIn error. %s", err)
+	checkNil(t, v, "CoerceIn nil")
+
+	for _, bad := range []interface{}{"123e4567e89b12d3a456426614174000", "123e4567-e89b-12d3-a456-42661417400g", 7} {
+		_, err = in.CoerceIn(bad)
+		checkNotNil(t, err, "CoerceIn of %v should fail", bad)
+	}
+	v, err = out.CoerceOut([16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00})
+	checkNil(t, err, "CoerceOut error. %s", err)
+	checkEqual(t, "123e4567-e89b-12d3-a456-426614174000", v, "CoerceOut bytes")
+
+	_, err = out.CoerceOut("not-a-uuid")
+	checkNotNil(t, err, "CoerceOut of an invalid UUID should fail")
+}